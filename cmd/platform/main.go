@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	// Embed the timezone database (about 450 KB), for systems without one, such
+	// as Windows: region suggestions compare the timezones of regions with the
+	// local time's (see commands.suggestRegion).
+	_ "time/tzdata"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -40,7 +44,5 @@ func main() {
 		}
 	})
 
-	if err := commands.Execute(cnf); err != nil {
-		os.Exit(1)
-	}
+	commands.Execute(cnf)
 }
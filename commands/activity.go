@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	_init "github.com/platformsh/cli/internal/init"
+)
+
+// activityPollInterval is how often activities are polled while waiting for them.
+var activityPollInterval = 2 * time.Second
+
+// addWaitFlags adds the --wait, --no-wait and --timeout flags to a command which starts activities.
+func addWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("wait", false, "Wait for the operation to complete (the default)")
+	cmd.Flags().Bool("no-wait", false, "Do not wait for the operation to complete")
+	cmd.Flags().Duration("timeout", 0,
+		"The maximum time to wait for the operation to complete, e.g. 10m (0 means no limit)")
+}
+
+// shouldWait returns whether a command should wait for the activities it starts.
+func shouldWait(cmd *cobra.Command) bool {
+	noWait, _ := cmd.Flags().GetBool("no-wait")
+	return !noWait
+}
+
+// waitForActivities waits for activities to complete, streaming their logs
+// and reporting their results on stderr. An error is returned if any activity
+// failed or was cancelled, or if the --timeout was reached.
+func waitForActivities(cmd *cobra.Command, client *api.Client, activities []*api.Activity) error {
+	ctx := cmd.Context()
+	if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	stderr := cmd.ErrOrStderr()
+	var failed int
+	for _, started := range activities {
+		a, err := waitForActivity(ctx, stderr, client, started)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return fmt.Errorf("timed out waiting for the activity %s", started.ID)
+			}
+			return err
+		}
+		switch {
+		case a.IsSuccess():
+			fmt.Fprintf(stderr, "Activity %s succeeded\n", color.GreenString(a.ID))
+		case a.State == api.ActivityStateCancelled:
+			fmt.Fprintf(stderr, "Activity %s was cancelled\n", color.YellowString(a.ID))
+			failed++
+		default:
+			fmt.Fprintf(stderr, "Activity %s failed\n", color.RedString(a.ID))
+			failed++
+		}
+	}
+	if failed == 1 && len(activities) == 1 {
+		return errors.New("the activity did not succeed")
+	} else if failed > 0 {
+		return fmt.Errorf("%d of %d activities did not succeed", failed, len(activities))
+	}
+	return nil
+}
+
+// waitForActivity follows an activity's log until it is complete, and returns its final state.
+func waitForActivity(
+	ctx context.Context,
+	stderr io.Writer,
+	client *api.Client,
+	a *api.Activity,
+) (*api.Activity, error) {
+	fmt.Fprintf(stderr, "Waiting for the activity %s (%s):\n", a.ID, activityDescription(a))
+
+	spinr := _init.NewSpinner(stderr)
+	spinr.Start()
+	defer spinr.Stop()
+
+	if _, ok := a.GetLink("log"); ok {
+		var lastTimestamp time.Time
+		printEntry := func(e api.ActivityLogEntry) {
+			spinr.Stop()
+			fmt.Fprintln(stderr, strings.TrimRight(e.Message, "\n"))
+			spinr.Start()
+			lastTimestamp = e.Timestamp
+		}
+		for {
+			sealed, err := client.StreamActivityLog(ctx, a, lastTimestamp, printEntry)
+			if err != nil {
+				if ctx.Err() != nil {
+					return a, ctx.Err()
+				}
+				// Fall back to polling the activity.
+				debugLog("Failed to stream the activity log: %s", err)
+				break
+			}
+			if sealed || a.IsComplete() {
+				break
+			}
+			// The stream ended before the log was complete: reconnect, after a
+			// delay if the activity is still running.
+			updated, err := client.GetActivity(ctx, a.Project, a.ID)
+			if err != nil {
+				return a, err
+			}
+			if a = updated; a.IsComplete() {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return a, ctx.Err()
+			case <-time.After(activityPollInterval):
+			}
+		}
+	}
+
+	return client.WaitForActivity(ctx, a, activityPollInterval)
+}
+
+// activityDescription returns an activity's description, without HTML-like tags.
+func activityDescription(a *api.Activity) string {
+	return regexTag.ReplaceAllString(a.Description, "")
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+// runWait runs a command which waits for the given activities, returning its stderr.
+func runWait(t *testing.T, cnf *config.Config, projectID string, ids []string, args ...string) (string, error) {
+	t.Helper()
+	cmd := &cobra.Command{
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			var activities []*api.Activity
+			for _, id := range ids {
+				a, err := client.GetActivity(cmd.Context(), projectID, id)
+				if err != nil {
+					return err
+				}
+				activities = append(activities, a)
+			}
+			return waitForActivities(cmd, client, activities)
+		},
+	}
+	addWaitFlags(cmd)
+	var stderr bytes.Buffer
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(context.Background())
+	return stderr.String(), err
+}
+
+func TestWaitForActivities(t *testing.T) {
+	interval := activityPollInterval
+	activityPollInterval = time.Millisecond
+	t.Cleanup(func() { activityPollInterval = interval })
+
+	cnf, apiHandler := newTestAPI(t)
+
+	succeeding := testActivity("abc123", "act1", "pending")
+	succeeding.AddLog("Building application")
+	succeeding.AddTransitions(
+		mockapi.ActivityTransition{State: "in_progress", CompletionPercent: 50, Log: []string{"Deploying"}},
+		mockapi.ActivityTransition{State: "complete", Result: "success", CompletionPercent: 100, Log: []string{"Done"}},
+	)
+	failing := testActivity("abc123", "act2", "in_progress")
+	failing.AddTransitions(mockapi.ActivityTransition{
+		State:  "complete",
+		Result: "failure",
+		Log:    []string{"Error: the build failed"},
+	})
+	stuck := testActivity("abc123", "act3", "pending")
+	stuck.Links = mockapi.MakeHALLinks("self=/projects/abc123/activities/act3")
+	apiHandler.SetProjectActivities("abc123", []*mockapi.Activity{succeeding, failing, stuck})
+
+	stderr, err := runWait(t, cnf, "abc123", []string{"act1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Waiting for the activity act1 (Test User pushed to main):\n"+
+		"Building application\nDeploying\nDone\n"+
+		"Activity act1 succeeded\n", stderr)
+
+	stderr, err = runWait(t, cnf, "abc123", []string{"act2"})
+	assert.EqualError(t, err, "the activity did not succeed")
+	assert.Contains(t, stderr, "Error: the build failed\nActivity act2 failed\n")
+
+	_, err = runWait(t, cnf, "abc123", []string{"act3"}, "--timeout", "50ms")
+	assert.EqualError(t, err, "timed out waiting for the activity act3")
+}
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/auth"
+	"github.com/platformsh/cli/internal/config"
+)
+
+// newAPIClient creates an authenticated API client for a native command.
+func newAPIClient(cmd *cobra.Command, cnf *config.Config) (*api.Client, error) {
+	legacyCLIClient, err := auth.NewLegacyCLIClient(withAPITransport(cmd, cnf),
+		makeLegacyCLIWrapper(cnf, cmd.OutOrStdout(), cmd.ErrOrStderr(), cmd.InOrStdin()))
+	if err != nil {
+		return nil, err
+	}
+	return api.NewClient(cnf.API.BaseURL, legacyCLIClient.HTTPClient)
+}
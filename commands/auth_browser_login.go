@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/platformsh/cli/internal/auth"
+	"github.com/platformsh/cli/internal/config"
+)
+
+func newAuthBrowserLoginCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "auth:browser-login",
+		Aliases: []string{"login"},
+		Short:   "Log in to " + cnf.Service.Name + " via a browser",
+		Args:    cobra.NoArgs,
+		RunE:    runAuthBrowserLogin,
+	}
+	cmd.Flags().Bool("no-browser", false, "Do not open a browser; print the login URL instead")
+	cmd.Flags().Bool("device", false, "Log in by entering a code on another device, e.g. when in a container or over SSH")
+	cmd.Flags().BoolP("force", "f", false, "Log in again, even if already logged in")
+	return cmd
+}
+
+func runAuthBrowserLogin(cmd *cobra.Command, _ []string) error {
+	cnf := config.FromContext(cmd.Context())
+	stderr := cmd.ErrOrStderr()
+
+	if viper.GetBool("no-interaction") {
+		return fmt.Errorf("non-interactive login is not supported: set an API token in the %s environment variable",
+			cnf.Application.EnvPrefix+"TOKEN")
+	}
+	if auth.APITokenFromEnv(cnf) != "" {
+		fmt.Fprintln(stderr, color.YellowString(
+			"Warning: an API token is set in the environment, so it will be used instead of this login session."))
+	}
+
+	if force, _ := cmd.Flags().GetBool("force"); !force {
+		if ts, err := auth.NewSessionTokenSource(cmd.Context(), cnf); err == nil {
+			if _, err := ts.Token(); err == nil {
+				fmt.Fprintln(stderr, "You are already logged in. Use --force to log in again.")
+				return nil
+			}
+		}
+	}
+
+	if device, _ := cmd.Flags().GetBool("device"); device {
+		if _, err := auth.DeviceLogin(cmd.Context(), cnf, &auth.DeviceLoginOptions{Stderr: stderr}); err != nil {
+			return err
+		}
+		fmt.Fprintln(stderr, color.GreenString("You are logged in."))
+		return nil
+	}
+
+	noBrowser, _ := cmd.Flags().GetBool("no-browser")
+	if _, err := auth.BrowserLogin(cmd.Context(), cnf, &auth.BrowserLoginOptions{
+		NoBrowser: noBrowser,
+		Stderr:    stderr,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stderr, color.GreenString("You are logged in."))
+	return nil
+}
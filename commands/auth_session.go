@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/auth"
+	"github.com/platformsh/cli/internal/config"
+)
+
+func newAuthSessionListCommand(cnf *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:     "auth:session:list",
+		Aliases: []string{"sessions"},
+		Short:   "List authentication sessions",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			sessions, err := auth.ListSessions(cnf)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "Session\tActive\tLogged in")
+			for _, s := range sessions {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", s.ID, yesNo(s.Active), yesNo(s.LoggedIn))
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newAuthSessionCreateCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth:session:create <name>",
+		Short: "Create a named authentication session",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			exists, err := auth.SessionExists(cnf, id)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return fmt.Errorf("the session %q already exists", id)
+			}
+			if err := auth.CreateSession(cnf, id); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Created session: %s\n", color.GreenString(id))
+
+			if switchTo, _ := cmd.Flags().GetBool("switch"); switchTo {
+				if err := auth.SwitchSession(cnf, id); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "Switched to session: %s\n", color.GreenString(id))
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "To log in, run: %s auth:browser-login --session %s\n",
+				cnf.Application.Executable, id)
+			return nil
+		},
+	}
+	cmd.Flags().Bool("switch", false, "Switch to the new session")
+	return cmd
+}
+
+func newAuthSessionSwitchCommand(cnf *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "auth:session:switch <name>",
+		Short: "Switch the active authentication session",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			exists, err := auth.SessionExists(cnf, id)
+			if err != nil {
+				return err
+			}
+			if !exists && id != auth.DefaultSessionID {
+				return fmt.Errorf("the session %q does not exist; create it with: %s auth:session:create %s",
+					id, cnf.Application.Executable, id)
+			}
+			if err := auth.SwitchSession(cnf, id); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Switched to session: %s\n", color.GreenString(id))
+			return nil
+		},
+	}
+}
+
+func newAuthSessionDeleteCommand(cnf *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "auth:session:delete <name>",
+		Short: "Delete an authentication session and its credentials",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			exists, err := auth.SessionExists(cnf, id)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("the session %q does not exist", id)
+			}
+			if err := auth.DeleteSession(cnf, id); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Deleted session: %s\n", id)
+			return nil
+		},
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
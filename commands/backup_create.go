@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+func newBackupCreateCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "backup:create [environment]",
+		Aliases: []string{"backup"},
+		Short:   "Make a backup of an environment",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectID, err := selectProject(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			var env *api.Environment
+			if len(args) > 0 {
+				env, err = findEnvironment(cmd, client, projectID, args[0])
+			} else {
+				env, err = selectEnvironment(cmd, cnf, client, projectID)
+			}
+			if err != nil {
+				return err
+			}
+			if env.Status == api.EnvironmentStatusInactive {
+				return fmt.Errorf("the environment %s is inactive, so it cannot be backed up", env.ID)
+			}
+
+			live, _ := cmd.Flags().GetBool("live")
+			stderr := cmd.ErrOrStderr()
+			if live {
+				fmt.Fprintf(stderr, "Creating a live backup of the environment %s\n", env.ID)
+			} else {
+				fmt.Fprintf(stderr, "Creating a backup of the environment %s\n", env.ID)
+			}
+			activities, err := client.CreateBackup(cmd.Context(), projectID, env.ID, !live)
+			if err != nil {
+				return err
+			}
+			if len(activities) == 0 {
+				return errors.New("no backup activity was started")
+			}
+			if name, ok := activities[0].Payload["backup_name"].(string); ok {
+				fmt.Fprintf(stderr, "Backup name: %s\n", name)
+			}
+			if shouldWait(cmd) {
+				return waitForActivities(cmd, client, activities)
+			}
+			return nil
+		},
+	}
+	addSelectionFlags(cmd, true)
+	cmd.Flags().Bool("live", false,
+		"Live backup: do not stop the environment. If set, this leaves the environment running "+
+			"and open to connections during the backup, which may result in inconsistent data")
+	addWaitFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,265 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+var backupListColumns = []tableColumn{
+	{Name: "created_at", Header: "Created"},
+	{Name: "id", Header: "Backup ID"},
+	{Name: "status", Header: "Status"},
+	{Name: "live", Header: "Live"},
+	{Name: "automated", Header: "Automated"},
+	{Name: "restorable", Header: "Restorable"},
+	{Name: "commit_id", Header: "Commit ID"},
+	{Name: "expires_at", Header: "Expires"},
+	{Name: "retention", Header: "Retention"},
+}
+
+func newBackupListCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "backup:list",
+		Aliases: []string{"backups"},
+		Short:   "List an environment's backups",
+		Long: "List an environment's backups.\n\n" +
+			"The --keep and --keep-age flags declare a retention policy: backups beyond the given number of " +
+			"most recent backups, or older than the given age, are flagged as outside the policy. " +
+			"Only completed or restorable backups count toward the number of backups kept.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runBackupList(cmd, cnf)
+		},
+	}
+	addSelectionFlags(cmd, true)
+	cmd.Flags().String("older-than", "", "Only list backups older than this age, e.g. 12h, 7d or 2w")
+	cmd.Flags().String("newer-than", "", "Only list backups newer than this age, e.g. 12h, 7d or 2w")
+	cmd.Flags().Int("keep", 0, "Retention policy: the number of most recent backups to keep")
+	cmd.Flags().String("keep-age", "", "Retention policy: the maximum age of backups to keep, e.g. 30d")
+	cmd.Flags().Bool("outside-retention", false, "Only list backups which are outside the retention policy")
+	addTableFlags(cmd, backupListColumns)
+	return cmd
+}
+
+// retentionPolicy describes which backups should be kept.
+type retentionPolicy struct {
+	Count  int           // The number of most recent backups to keep (0 for no limit).
+	MaxAge time.Duration // The maximum age of backups to keep (0 for no limit).
+}
+
+func (p retentionPolicy) isSet() bool {
+	return p.Count > 0 || p.MaxAge > 0
+}
+
+// outside returns whether a backup falls outside the policy, given the number
+// of more recent backups kept by the policy, and its age.
+func (p retentionPolicy) outside(newerKept int, age time.Duration) bool {
+	return (p.Count > 0 && newerKept >= p.Count) || (p.MaxAge > 0 && age > p.MaxAge)
+}
+
+// countsTowardRetention returns whether a backup counts toward the number of
+// backups kept by a retention policy. Failed or unfinished backups do not.
+func countsTowardRetention(b *api.Backup) bool {
+	return b.Restorable || b.Status == "CREATED"
+}
+
+func runBackupList(cmd *cobra.Command, cnf *config.Config) error {
+	flags := cmd.Flags()
+	olderThan, err := ageFlag(cmd, "older-than")
+	if err != nil {
+		return err
+	}
+	newerThan, err := ageFlag(cmd, "newer-than")
+	if err != nil {
+		return err
+	}
+	var policy retentionPolicy
+	policy.Count, _ = flags.GetInt("keep")
+	if policy.MaxAge, err = ageFlag(cmd, "keep-age"); err != nil {
+		return err
+	}
+	outsideOnly, _ := flags.GetBool("outside-retention")
+	if outsideOnly && !policy.isSet() {
+		return errors.New("the --outside-retention flag requires a retention policy: use --keep or --keep-age")
+	}
+
+	projectID, err := selectProject(cmd, cnf)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient(cmd, cnf)
+	if err != nil {
+		return err
+	}
+	env, err := selectEnvironment(cmd, cnf, client, projectID)
+	if err != nil {
+		return err
+	}
+	backups, err := client.ListBackups(cmd.Context(), projectID, env.ID)
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(backups, func(a, b *api.Backup) int { return b.CreatedAt.Compare(a.CreatedAt) })
+
+	t := &table{Columns: backupListColumns, DefaultColumns: []string{"created_at", "id", "restorable"}}
+	if policy.isSet() {
+		t.DefaultColumns = append(t.DefaultColumns, "retention")
+	}
+	now := time.Now()
+	var outsideCount, keptCount int
+	for _, b := range backups {
+		age := now.Sub(b.CreatedAt)
+		outside := policy.outside(keptCount, age)
+		if outside {
+			outsideCount++
+		} else if countsTowardRetention(b) {
+			keptCount++
+		}
+		if (olderThan > 0 && age < olderThan) || (newerThan > 0 && age > newerThan) || (outsideOnly && !outside) {
+			continue
+		}
+		row := backupRow(b)
+		if policy.isSet() {
+			row["retention"] = "keep"
+			if outside {
+				row["retention"] = "outside policy"
+			}
+		}
+		t.Rows = append(t.Rows, row)
+	}
+
+	stderr := cmd.ErrOrStderr()
+	if len(t.Rows) == 0 {
+		fmt.Fprintln(stderr, "No backups found.")
+		return nil
+	}
+	format, _ := flags.GetString("format")
+	if format == "table" {
+		fmt.Fprintf(stderr, "Backups of the environment %s:\n", env.ID)
+	}
+	if err := t.render(cmd); err != nil {
+		return err
+	}
+	if format == "table" && policy.isSet() {
+		fmt.Fprintln(stderr)
+		fmt.Fprintf(stderr, "%d of %d backup(s) are outside the retention policy.\n", outsideCount, len(backups))
+	}
+	return nil
+}
+
+func backupRow(b *api.Backup) map[string]any {
+	row := map[string]any{
+		"created_at": b.CreatedAt,
+		"id":         b.ID,
+		"status":     b.Status,
+		"live":       !b.Safe,
+		"automated":  b.Automated,
+		"restorable": b.Restorable,
+		"commit_id":  b.CommitID,
+		"expires_at": b.ExpiresAt,
+		"retention":  "",
+	}
+	if b.ExpiresAt != nil && b.ExpiresAt.IsZero() {
+		row["expires_at"] = nil
+	}
+	return row
+}
+
+var ageDaysWeeks = regexp.MustCompile(`^(\d+)([dw])$`)
+
+// ageFlag reads a flag containing an age, which is a duration that may also
+// be given in days (e.g. 7d) or weeks (e.g. 2w).
+func ageFlag(cmd *cobra.Command, name string) (time.Duration, error) {
+	s, _ := cmd.Flags().GetString(name)
+	if s == "" {
+		return 0, nil
+	}
+	if m := ageDaysWeeks.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		day := 24 * time.Hour
+		if m[2] == "w" {
+			return time.Duration(n) * 7 * day, nil
+		}
+		return time.Duration(n) * day, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid --%s value: %s (examples: 12h, 7d, 2w)", name, s)
+	}
+	return d, nil
+}
+
+// findBackup finds an environment's backup by ID, or, if the ID is empty,
+// its most recent restorable backup.
+func findBackup(cmd *cobra.Command, client *api.Client, projectID, environmentID, id string) (*api.Backup, error) {
+	if id != "" {
+		b, err := client.GetBackup(cmd.Context(), projectID, environmentID, id)
+		if api.IsNotFound(err) {
+			return nil, fmt.Errorf("backup not found: %s", id)
+		}
+		return b, err
+	}
+	backups, err := client.ListBackups(cmd.Context(), projectID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	var latest *api.Backup
+	for _, b := range backups {
+		if b.Restorable && (latest == nil || b.CreatedAt.After(latest.CreatedAt)) {
+			latest = b
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no restorable backups found for the environment %s", environmentID)
+	}
+	return latest, nil
+}
+
+func newBackupGetCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup:get [backup]",
+		Short: "View an environment's backup (the most recent restorable backup by default)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectID, err := selectProject(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			env, err := selectEnvironment(cmd, cnf, client, projectID)
+			if err != nil {
+				return err
+			}
+			var id string
+			if len(args) > 0 {
+				id = args[0]
+			}
+			b, err := findBackup(cmd, client, projectID, env.ID, id)
+			if err != nil {
+				return err
+			}
+			props, err := client.GetBackupProperties(cmd.Context(), projectID, env.ID, b.ID)
+			if err != nil {
+				return err
+			}
+			property, _ := cmd.Flags().GetString("property")
+			return renderProperties(cmd, props, property)
+		},
+	}
+	addSelectionFlags(cmd, true)
+	cmd.Flags().StringP("property", "P", "", "The backup property to view")
+	addPropertyFlags(cmd)
+	return cmd
+}
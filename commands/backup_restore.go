@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+func newBackupRestoreCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup:restore [backup]",
+		Short: "Restore an environment backup (the most recent restorable backup by default)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectID, err := selectProject(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			env, err := selectEnvironment(cmd, cnf, client, projectID)
+			if err != nil {
+				return err
+			}
+			var id string
+			if len(args) > 0 {
+				id = args[0]
+			}
+			b, err := findBackup(cmd, client, projectID, env.ID, id)
+			if err != nil {
+				return err
+			}
+			if !b.Restorable {
+				return fmt.Errorf("the backup %s is not restorable", b.ID)
+			}
+
+			flags := cmd.Flags()
+			noCode, _ := flags.GetBool("no-code")
+			opts := api.RestoreOptions{RestoreCode: !noCode}
+			opts.EnvironmentName, _ = flags.GetString("target")
+			opts.BranchFrom, _ = flags.GetString("branch-from")
+			target := env.ID
+			if opts.EnvironmentName != "" {
+				target = opts.EnvironmentName
+			}
+
+			question := fmt.Sprintf("Are you sure you want to restore the backup %s (created %s) to the environment %s?",
+				b.ID, formatValue(b.CreatedAt), target)
+			if !confirmAction(question) {
+				return nil
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Restoring the backup %s to the environment %s\n", b.ID, target)
+			activities, err := client.RestoreBackup(cmd.Context(), projectID, b, opts)
+			if err != nil {
+				return err
+			}
+			if len(activities) > 0 && shouldWait(cmd) {
+				return waitForActivities(cmd, client, activities)
+			}
+			return nil
+		},
+	}
+	addSelectionFlags(cmd, true)
+	cmd.Flags().String("target", "", "The environment to restore to (the backup's own environment by default)")
+	cmd.Flags().String("branch-from", "", "If the target environment does not exist, the parent to branch it from")
+	cmd.Flags().Bool("no-code", false, "Do not restore code, only data")
+	addWaitFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+func TestBackupList(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetEnvironments(testEnvironments("abc123"))
+	now := time.Now()
+	makeBackup := func(id string, age time.Duration, restorable bool) *mockapi.Backup {
+		return &mockapi.Backup{ID: id, EnvironmentID: "main", Status: "CREATED", Safe: true,
+			Restorable: restorable, CreatedAt: now.Add(-age)}
+	}
+	day := 24 * time.Hour
+	apiHandler.SetProjectBackups("abc123", []*mockapi.Backup{
+		makeBackup("b1", 1*day, true),
+		makeBackup("b2", 3*day, false),
+		{ID: "b5", EnvironmentID: "main", Status: "FAILED", Safe: true, CreatedAt: now.Add(-5 * day)},
+		makeBackup("b3", 10*day, true),
+		makeBackup("b4", 40*day, true),
+	})
+
+	out, err := runCommand(t, newBackupListCommand(cnf), "-p", "abc123", "-e", "main",
+		"--format", "csv", "--columns", "id,restorable")
+	require.NoError(t, err)
+	assert.Equal(t, "Backup ID,Restorable\nb1,true\nb2,false\nb5,false\nb3,true\nb4,true\n", out)
+
+	out, err = runCommand(t, newBackupListCommand(cnf), "-p", "abc123", "-e", "main",
+		"--format", "csv", "--columns", "id", "--older-than", "2d", "--newer-than", "2w")
+	require.NoError(t, err)
+	assert.Equal(t, "Backup ID\nb2\nb5\nb3\n", out)
+
+	out, err = runCommand(t, newBackupListCommand(cnf), "-p", "abc123", "-e", "main",
+		"--format", "csv", "--columns", "id,retention", "--keep", "3", "--keep-age", "30d")
+	require.NoError(t, err)
+	// The failed backup does not count toward the number of backups kept.
+	assert.Equal(t, "Backup ID,Retention\nb1,keep\nb2,keep\nb5,keep\nb3,keep\nb4,outside policy\n", out)
+
+	out, err = runCommand(t, newBackupListCommand(cnf), "-p", "abc123", "-e", "main",
+		"--format", "csv", "--columns", "id", "--keep", "2", "--outside-retention")
+	require.NoError(t, err)
+	assert.Equal(t, "Backup ID\nb5\nb3\nb4\n", out)
+
+	_, err = runCommand(t, newBackupListCommand(cnf), "-p", "abc123", "-e", "main", "--older-than", "soon")
+	assert.EqualError(t, err, "invalid --older-than value: soon (examples: 12h, 7d, 2w)")
+
+	// The most recent restorable backup is the default.
+	out, err = runCommand(t, newBackupGetCommand(cnf), "-p", "abc123", "-e", "main", "-P", "id")
+	require.NoError(t, err)
+	assert.Equal(t, "b1\n", out)
+
+	_, err = runCommand(t, newBackupGetCommand(cnf), "-p", "abc123", "-e", "main", "missing")
+	assert.EqualError(t, err, "backup not found: missing")
+}
+
+func TestBackupCreateRestore(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetEnvironments(testEnvironments("abc123"))
+
+	_, err := runCommand(t, newBackupCreateCommand(cnf), "-p", "abc123", "dev")
+	assert.EqualError(t, err, "the environment dev is inactive, so it cannot be backed up")
+
+	_, err = runCommand(t, newBackupCreateCommand(cnf), "-p", "abc123", "-e", "main", "--live")
+	require.NoError(t, err)
+
+	out, err := runCommand(t, newBackupGetCommand(cnf), "-p", "abc123", "-e", "main", "--format", "json")
+	require.NoError(t, err)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &props))
+	assert.Equal(t, false, props["safe"])
+
+	_, err = runCommand(t, newBackupRestoreCommand(cnf), "-p", "abc123", "-e", "main",
+		props["id"].(string), "--target", "staging")
+	require.NoError(t, err)
+
+	_, err = runCommand(t, newBackupRestoreCommand(cnf), "-p", "abc123", "-e", "staging")
+	assert.EqualError(t, err, "no restorable backups found for the environment staging")
+}
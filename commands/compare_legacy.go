@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/platformsh/cli/internal/config"
+)
+
+// legacyRunFunc runs a legacy CLI command, writing its output to stdout and stderr.
+type legacyRunFunc func(ctx context.Context, stdout, stderr io.Writer, args []string) error
+
+// legacyComparisonRunner returns a function running legacy CLI commands, non-interactively.
+func legacyComparisonRunner(cnf *config.Config) legacyRunFunc {
+	return func(ctx context.Context, stdout, stderr io.Writer, args []string) error {
+		c := makeLegacyCLIWrapper(cnf, stdout, stderr, nil)
+		c.DisableInteraction = true
+		return c.Exec(ctx, args...)
+	}
+}
+
+// withLegacyComparison wraps a native command so that, in the hidden
+// --compare-legacy mode (or with the {ENV_PREFIX}COMPARE_LEGACY environment
+// variable), it runs both the native command and the legacy CLI command it
+// replaces, and reports the differences between their output.
+func withLegacyComparison(n nativeCommand, runLegacy legacyRunFunc) {
+	run := n.RunE
+	if run == nil {
+		if n.Run == nil {
+			return
+		}
+		runFunc := n.Run
+		run = func(cmd *cobra.Command, args []string) error {
+			runFunc(cmd, args)
+			return nil
+		}
+		n.Run = nil
+	}
+	n.RunE = func(cmd *cobra.Command, args []string) error {
+		if !viper.GetBool("compare-legacy") {
+			return run(cmd, args)
+		}
+		comparison, err := compareWithLegacy(cmd, n, args, run, runLegacy)
+		if err != nil {
+			return err
+		}
+		if err := writeJSON(cmd.OutOrStdout(), comparison); err != nil {
+			return err
+		}
+		if !comparison.Equal {
+			return errors.New("the output of the native command differs from the legacy CLI")
+		}
+		return nil
+	}
+}
+
+// legacyComparison is the result of comparing a native command with the legacy CLI.
+type legacyComparison struct {
+	Command     string   `json:"command"`
+	LegacyArgs  []string `json:"legacy_args"`
+	NativeFlags []string `json:"native_flags,omitempty"` // Flags not passed to the legacy CLI, which lacks them.
+
+	Equal       bool               `json:"equal"`
+	NativeError string             `json:"native_error,omitempty"`
+	LegacyError string             `json:"legacy_error,omitempty"`
+	Differences []outputDifference `json:"differences"`
+}
+
+// outputDifference is a line found in only one of the compared outputs.
+type outputDifference struct {
+	// Output is the output containing the line: "native" or "legacy".
+	Output string `json:"output"`
+	// Line is the line number, in the normalized output.
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// compareWithLegacy runs a native command and the equivalent legacy CLI
+// command, and compares their (normalized) standard output. Commands which
+// may make changes are refused, as they would run twice, as are commands which
+// only exist natively.
+func compareWithLegacy(
+	cmd *cobra.Command,
+	n nativeCommand,
+	args []string,
+	runNative func(*cobra.Command, []string) error,
+	runLegacy legacyRunFunc,
+) (*legacyComparison, error) {
+	if len(n.Replaces) == 0 {
+		return nil, fmt.Errorf("the command %s cannot be compared with the legacy CLI, as it only exists natively",
+			cmd.Name())
+	}
+	if n.ReadOnly == nil || !n.ReadOnly(args) {
+		return nil, fmt.Errorf("the command %s cannot be compared with the legacy CLI, as it may make changes",
+			cmd.Name())
+	}
+
+	c := &legacyComparison{Command: cmd.Name()}
+	c.LegacyArgs, c.NativeFlags = legacyCommandArgs(n, cmd, args)
+
+	var nativeOut, nativeErr bytes.Buffer
+	stdout, stderr := cmd.OutOrStdout(), cmd.ErrOrStderr()
+	cmd.SetOut(&nativeOut)
+	cmd.SetErr(&nativeErr)
+	err := runNative(cmd, args)
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	if err != nil {
+		c.NativeError = err.Error()
+	}
+
+	var legacyOut, legacyErr bytes.Buffer
+	if err := runLegacy(cmd.Context(), &legacyOut, &legacyErr, c.LegacyArgs); err != nil {
+		c.LegacyError = err.Error()
+		if msg := strings.TrimSpace(legacyErr.String()); msg != "" {
+			c.LegacyError = msg
+		}
+	}
+
+	c.Differences = diffOutputLines(normalizeOutput(nativeOut.String()), normalizeOutput(legacyOut.String()))
+	c.Equal = len(c.Differences) == 0 && (c.NativeError == "") == (c.LegacyError == "")
+	return c, nil
+}
+
+// legacyCommandArgs builds the legacy CLI arguments equivalent to a native
+// command's arguments and changed flags. It also returns the changed flags
+// which the legacy command does not have, which are left out.
+func legacyCommandArgs(n nativeCommand, cmd *cobra.Command, args []string) (legacyArgs, nativeFlags []string) {
+	legacyArgs = []string{n.legacyName()}
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if slices.Contains(n.NativeFlags, f.Name) {
+			nativeFlags = append(nativeFlags, "--"+f.Name)
+			return
+		}
+		switch v := f.Value.(type) {
+		case pflag.SliceValue:
+			for _, s := range v.GetSlice() {
+				legacyArgs = append(legacyArgs, "--"+f.Name+"="+s)
+			}
+		default:
+			if f.Value.Type() == "bool" {
+				if f.Value.String() == "true" {
+					legacyArgs = append(legacyArgs, "--"+f.Name)
+				}
+				return
+			}
+			legacyArgs = append(legacyArgs, "--"+f.Name+"="+f.Value.String())
+		}
+	})
+	legacyArgs = append(legacyArgs, "--no-interaction")
+	return append(legacyArgs, args...), nativeFlags
+}
+
+var (
+	ansiEscapePattern  = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	tableBorderPattern = regexp.MustCompile(`^\+[-+=]*\+$`)
+)
+
+// normalizeOutput removes known differences from command output which do
+// not affect its meaning: colors, whitespace, blank lines, table borders, and
+// the padding of table columns.
+func normalizeOutput(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(ansiEscapePattern.ReplaceAllString(s, ""), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || tableBorderPattern.MatchString(line) {
+			continue
+		}
+		if len(line) > 1 && strings.HasPrefix(line, "|") && strings.HasSuffix(line, "|") {
+			cells := strings.Split(line[1:len(line)-1], "|")
+			for i, cell := range cells {
+				cells[i] = strings.Join(strings.Fields(cell), " ")
+			}
+			lines = append(lines, strings.Join(cells, " | "))
+			continue
+		}
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+	return lines
+}
+
+// diffOutputLines returns the lines which are only in one of the native or
+// legacy outputs, based on their longest common subsequence.
+func diffOutputLines(native, legacy []string) []outputDifference {
+	// common[i][j] is the length of the longest common subsequence of native[i:] and legacy[j:].
+	common := make([][]int, len(native)+1)
+	for i := range common {
+		common[i] = make([]int, len(legacy)+1)
+	}
+	for i := len(native) - 1; i >= 0; i-- {
+		for j := len(legacy) - 1; j >= 0; j-- {
+			if native[i] == legacy[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	diffs := []outputDifference{}
+	i, j := 0, 0
+	for i < len(native) || j < len(legacy) {
+		switch {
+		case i < len(native) && j < len(legacy) && native[i] == legacy[j]:
+			i++
+			j++
+		case j == len(legacy) || (i < len(native) && common[i+1][j] >= common[i][j+1]):
+			diffs = append(diffs, outputDifference{Output: "native", Line: i + 1, Text: native[i]})
+			i++
+		default:
+			diffs = append(diffs, outputDifference{Output: "legacy", Line: j + 1, Text: legacy[j]})
+			j++
+		}
+	}
+	return diffs
+}
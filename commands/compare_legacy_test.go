@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/internal/legacy"
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+func TestNormalizeOutput(t *testing.T) {
+	native := "+------+--------+\n| ID   | Title  |\n+------+--------+\n| abc  | Site   |\n+------+--------+\n"
+	legacyOutput := "\x1b[32m+-----+-------+\x1b[0m\n| ID  |  Title |\n+=====+=======+\n" +
+		"|  abc | Site |\n+-----+-------+\n\n"
+	assert.Equal(t, []string{"ID | Title", "abc | Site"}, normalizeOutput(native))
+	assert.Empty(t, diffOutputLines(normalizeOutput(native), normalizeOutput(legacyOutput)))
+
+	assert.Equal(t, []outputDifference{
+		{Output: "native", Line: 2, Text: "b,2"},
+		{Output: "legacy", Line: 2, Text: "b,3"},
+		{Output: "legacy", Line: 4, Text: "d,4"},
+	}, diffOutputLines(
+		normalizeOutput("a,1\nb,2\nc,3\n"),
+		normalizeOutput("a,1\nb,3\nc,3\nd,4\n"),
+	))
+}
+
+func TestCompareLegacy(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetMyUser(&mockapi.User{ID: "my-user-id"})
+	apiHandler.SetOrgs([]*mockapi.Org{{ID: "org-1", Name: "mine", Label: "Mine", Owner: "my-user-id"}})
+	apiHandler.SetProjects([]*mockapi.Project{{ID: "abc123", Title: "Site", Organization: "org-1"}})
+	apiHandler.SetUserGrants([]*mockapi.UserGrant{
+		{ResourceID: "abc123", ResourceType: "project", OrganizationID: "org-1", UserID: "my-user-id"},
+	})
+
+	viper.Set("compare-legacy", true)
+	t.Cleanup(func() { viper.Set("compare-legacy", false) })
+
+	var legacyArgs []string
+	legacyOutput := "ID\tTitle\nabc123\tSite\n"
+	fakeLegacy := func(_ context.Context, stdout, _ io.Writer, args []string) error {
+		legacyArgs = args
+		_, err := io.WriteString(stdout, legacyOutput)
+		return err
+	}
+	compare := func(n nativeCommand, args ...string) (*legacyComparison, error) {
+		withLegacyComparison(n, fakeLegacy)
+		out, err := runCommand(t, n.Command, args...)
+		if !strings.HasPrefix(out, "{") {
+			return nil, err
+		}
+		// Errors are followed by the usage, as the command runs without the root command.
+		var c legacyComparison
+		require.NoError(t, json.NewDecoder(strings.NewReader(out)).Decode(&c))
+		return &c, err
+	}
+
+	projectList := func() nativeCommand {
+		return nativeCommand{Command: newProjectListCommand(cnf), Replaces: []string{"project:list"}, ReadOnly: readOnly}
+	}
+	c, err := compare(projectList(), "--format", "tsv", "--columns", "id,title")
+	require.NoError(t, err)
+	assert.True(t, c.Equal)
+	assert.Empty(t, c.Differences)
+	assert.Equal(t, []string{"project:list", "--columns=id", "--columns=title", "--format=tsv", "--no-interaction"},
+		legacyArgs)
+
+	legacyOutput = "ID\tTitle\nabc123\tOld site\n"
+	c, err = compare(projectList(), "--format", "tsv", "--columns", "id,title")
+	assert.EqualError(t, err, "the output of the native command differs from the legacy CLI")
+	assert.False(t, c.Equal)
+	assert.Equal(t, []outputDifference{
+		{Output: "native", Line: 2, Text: "abc123 Site"},
+		{Output: "legacy", Line: 2, Text: "abc123 Old site"},
+	}, c.Differences)
+
+	// Commands which may make changes are refused, and nothing is run.
+	legacyArgs = nil
+	_, err = compare(nativeCommand{Command: newProjectInfoCommand(cnf), Replaces: []string{"project:info"},
+		ReadOnly: readOnlyWithoutValue}, "-p", "abc123", "title", "New title")
+	assert.EqualError(t, err, "the command project:info cannot be compared with the legacy CLI, as it may make changes")
+	_, err = compare(nativeCommand{Command: newVariableDeleteCommand(cnf), Replaces: []string{"variable:delete"}},
+		"-p", "abc123", "env:FOO")
+	assert.ErrorContains(t, err, "the command variable:delete cannot be compared")
+	_, err = compare(nativeCommand{Command: newRegionListCommand(cnf)}, "--format", "csv")
+	assert.EqualError(t, err, "the command region:list cannot be compared with the legacy CLI, as it only exists natively")
+	assert.Nil(t, legacyArgs)
+
+	// Flags which the legacy command does not have are left out.
+	backupList := nativeCommand{Command: newBackupListCommand(cnf), Replaces: []string{"backup:list"},
+		ReadOnly: readOnly, NativeFlags: []string{"older-than", "keep"}}
+	require.NoError(t, backupList.ParseFlags([]string{"--older-than", "2d", "--keep", "3", "--format", "csv"}))
+	legacyArgs, nativeFlags := legacyCommandArgs(backupList, backupList.Command, nil)
+	assert.Equal(t, []string{"backup:list", "--format=csv", "--no-interaction"}, legacyArgs)
+	assert.Equal(t, []string{"--keep", "--older-than"}, nativeFlags)
+}
+
+// TestLegacyParity compares read-only native commands with the legacy CLI,
+// both running against the mock API. It needs a build with the legacy CLI
+// embedded, and is skipped otherwise: run it with "make test-parity".
+func TestLegacyParity(t *testing.T) {
+	if !legacy.Available() {
+		t.Skip("the legacy CLI is not available in this build")
+	}
+	cnf, apiHandler := newTestAPI(t)
+	cnf.Application.TempSubDir = "test-cli-tmp"
+	testOrgs(apiHandler)
+	testUserGrants(apiHandler)
+	apiHandler.SetOrgMembers("org-1", []*mockapi.OrgMember{
+		{ID: "m1", OrganizationID: "org-1", UserID: "my-user-id", Owner: true, Permissions: []string{"admin"}},
+	})
+
+	cases := [][]string{
+		{"project:list", "--format", "csv"},
+		{"project:info", "-p", "abc123", "--format", "csv"},
+		{"organization:list", "--format", "csv"},
+		{"organization:info", "-o", "mine", "--format", "csv"},
+		{"user:list", "-p", "abc123", "--format", "csv"},
+	}
+	router := newCommandRouter(cnf, nativeCommandRegistry)
+	viper.Set("compare-legacy", true)
+	t.Cleanup(func() { viper.Set("compare-legacy", false) })
+	for _, args := range cases {
+		t.Run(args[0], func(t *testing.T) {
+			cmd := router.find(args[0])
+			require.NotNil(t, cmd)
+			var stdout bytes.Buffer
+			cmd.SetOut(&stdout)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(args[1:])
+			err := cmd.ExecuteContext(context.Background())
+			assert.NoError(t, err, stdout.String())
+		})
+	}
+}
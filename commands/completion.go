@@ -3,7 +3,10 @@ package commands
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -26,12 +29,12 @@ func newCompletionCommand(cnf *config.Config) *cobra.Command {
 			c := makeLegacyCLIWrapper(cnf, &b, cmd.ErrOrStderr(), cmd.InOrStdin())
 
 			if err := c.Exec(cmd.Context(), completionArgs...); err != nil {
-				exitWithError(err)
+				exitWithError(cnf, err)
 			}
 
 			pharPath, err := c.PharPath()
 			if err != nil {
-				exitWithError(err)
+				exitWithError(cnf, err)
 			}
 
 			completions := strings.ReplaceAll(
@@ -48,3 +51,45 @@ func newCompletionCommand(cnf *config.Config) *cobra.Command {
 		},
 	}
 }
+
+// newCompletionHookCommand creates the hidden command which the shell
+// completion script calls to complete a command line. It merges the legacy
+// CLI's suggestions with those of the native commands.
+func newCompletionHookCommand(cnf *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:                "_completion",
+		Hidden:             true,
+		DisableFlagParsing: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			var b bytes.Buffer
+			c := makeLegacyCLIWrapper(cnf, &b, cmd.ErrOrStderr(), cmd.InOrStdin())
+			if err := c.Exec(cmd.Context(), append([]string{"_completion"}, args...)...); err != nil {
+				exitWithError(cnf, err)
+			}
+
+			// Generating the completion script does not need merging.
+			line := os.Getenv("CMDLINE_CONTENTS")
+			if line == "" || slices.Contains(args, "-g") || slices.Contains(args, "--generate-hook") {
+				_, _ = cmd.OutOrStdout().Write(b.Bytes())
+				return
+			}
+			if i, err := strconv.Atoi(os.Getenv("CMDLINE_CURSOR_INDEX")); err == nil && i >= 0 && i < len(line) {
+				line = line[:i]
+			}
+			router := newCommandRouter(cnf, nativeCommandRegistry)
+			suggestions := mergeCompletions(strings.Split(b.String(), "\n"), router.completeNative(line))
+			fmt.Fprintln(cmd.OutOrStdout(), strings.Join(suggestions, "\n"))
+		},
+	}
+}
+
+// mergeCompletions merges native suggestions into the legacy CLI's, without duplicates.
+func mergeCompletions(legacy, native []string) []string {
+	var merged []string
+	for _, s := range slices.Concat(legacy, native) {
+		if s = strings.TrimSpace(s); s != "" && !slices.Contains(merged, s) {
+			merged = append(merged, s)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/config"
+)
+
+func newEnvironmentInfoCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "environment:info [property] [value]",
+		Short: "Read or set properties for an environment",
+		Args:  cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				// Setting properties is handled by the legacy CLI.
+				return makeLegacyCLIWrapper(cnf, cmd.OutOrStdout(), cmd.ErrOrStderr(), cmd.InOrStdin()).
+					Exec(cmd.Context(), removeWrapperFlags(os.Args[1:])...)
+			}
+			projectID, err := selectProject(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			env, err := selectEnvironment(cmd, cnf, client, projectID)
+			if err != nil {
+				return err
+			}
+			props, err := client.GetEnvironmentProperties(cmd.Context(), projectID, env.ID)
+			if err != nil {
+				return err
+			}
+			var property string
+			if len(args) > 0 {
+				property = args[0]
+			}
+			return renderProperties(cmd, props, property)
+		},
+	}
+	addSelectionFlags(cmd, true)
+	addPropertyFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+var environmentListColumns = []tableColumn{
+	{Name: "id", Header: "ID"},
+	{Name: "title", Header: "Title"},
+	{Name: "status", Header: "Status"},
+	{Name: "type", Header: "Type"},
+	{Name: "machine_name", Header: "Machine name"},
+	{Name: "parent", Header: "Parent"},
+	{Name: "created", Header: "Created"},
+	{Name: "updated", Header: "Updated"},
+}
+
+func newEnvironmentListCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "environment:list",
+		Aliases: []string{"environments", "env"},
+		Short:   "Get a list of environments",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runEnvironmentList(cmd, cnf)
+		},
+	}
+	addSelectionFlags(cmd, false)
+	cmd.Flags().BoolP("no-inactive", "I", false, "Do not show inactive environments")
+	cmd.Flags().StringSlice("status", nil, "Filter environments by status (active, inactive, dirty, paused)")
+	cmd.Flags().StringSlice("type", nil, "Filter the list by environment type(s)")
+	cmd.Flags().Bool("pipe", false, "Output a simple list of environment IDs")
+	cmd.Flags().String("sort", "", "A property to sort by (by default, environments are listed as a tree)")
+	cmd.Flags().Bool("reverse", false, "Sort in reverse (descending) order")
+	addTableFlags(cmd, environmentListColumns)
+	return cmd
+}
+
+func runEnvironmentList(cmd *cobra.Command, cnf *config.Config) error {
+	projectID, err := selectProject(cmd, cnf)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient(cmd, cnf)
+	if err != nil {
+		return err
+	}
+	envs, err := client.ListEnvironments(cmd.Context(), projectID)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	noInactive, _ := flags.GetBool("no-inactive")
+	statuses, _ := flags.GetStringSlice("status")
+	types, _ := flags.GetStringSlice("type")
+	envs = slices.DeleteFunc(envs, func(e *api.Environment) bool {
+		return (noInactive && e.Status == api.EnvironmentStatusInactive) ||
+			(len(statuses) > 0 && !slices.Contains(statuses, e.Status)) ||
+			(len(types) > 0 && !slices.Contains(types, e.Type))
+	})
+
+	format, _ := flags.GetString("format")
+	sortField, _ := flags.GetString("sort")
+	reverse, _ := flags.GetBool("reverse")
+
+	t := &table{Columns: environmentListColumns, DefaultColumns: []string{"id", "title", "status", "type"}}
+	if sortField == "" {
+		// List environments as a tree, indenting children in the table format.
+		for _, n := range environmentTree(envs) {
+			row := environmentRow(n.env)
+			if format == "table" {
+				row["id"] = strings.Repeat("   ", n.depth) + n.env.ID
+			}
+			t.Rows = append(t.Rows, row)
+		}
+		if reverse {
+			slices.Reverse(t.Rows)
+		}
+	} else {
+		for _, e := range envs {
+			t.Rows = append(t.Rows, environmentRow(e))
+		}
+		sortRows(t.Rows, sortField, reverse)
+	}
+
+	if pipe, _ := flags.GetBool("pipe"); pipe {
+		for _, row := range t.Rows {
+			fmt.Fprintln(cmd.OutOrStdout(), strings.TrimSpace(row["id"].(string)))
+		}
+		return nil
+	}
+
+	stderr := cmd.ErrOrStderr()
+	if len(t.Rows) == 0 {
+		fmt.Fprintln(stderr, "No environments found.")
+		return nil
+	}
+	if format == "table" {
+		fmt.Fprintf(stderr, "Your environments in the project %s are: \n", projectID)
+	}
+	if err := t.render(cmd); err != nil {
+		return err
+	}
+	if format == "table" {
+		fmt.Fprintln(stderr)
+		fmt.Fprintf(stderr, "To view the environment's info, run: %s environment:info -e [id]\n",
+			cnf.Application.Executable)
+	}
+	return nil
+}
+
+func environmentRow(e *api.Environment) map[string]any {
+	row := map[string]any{
+		"id":           e.ID,
+		"title":        e.Title,
+		"status":       e.Status,
+		"type":         e.Type,
+		"machine_name": e.MachineName,
+		"parent":       nil,
+		"created":      e.CreatedAt,
+		"updated":      e.UpdatedAt,
+	}
+	if e.Parent != nil {
+		row["parent"] = *e.Parent
+	}
+	return row
+}
+
+type environmentTreeNode struct {
+	env   *api.Environment
+	depth int
+}
+
+// environmentTree orders environments depth-first by their parents, with
+// siblings ordered by title.
+func environmentTree(envs []*api.Environment) []environmentTreeNode {
+	byID := make(map[string]bool, len(envs))
+	for _, e := range envs {
+		byID[e.ID] = true
+	}
+	children := make(map[string][]*api.Environment)
+	for _, e := range envs {
+		var parent string
+		if e.Parent != nil && byID[*e.Parent] {
+			parent = *e.Parent
+		}
+		children[parent] = append(children[parent], e)
+	}
+
+	var (
+		nodes []environmentTreeNode
+		walk  func(parent string, depth int)
+	)
+	walk = func(parent string, depth int) {
+		siblings := children[parent]
+		slices.SortStableFunc(siblings, func(a, b *api.Environment) int {
+			return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		})
+		for _, e := range siblings {
+			nodes = append(nodes, environmentTreeNode{env: e, depth: depth})
+			walk(e.ID, depth+1)
+		}
+	}
+	walk("", 0)
+	return nodes
+}
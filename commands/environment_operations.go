@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/symfony-cli/terminal"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+// environmentOperation describes a command which runs an operation on environments.
+type environmentOperation struct {
+	Use      string
+	Aliases  []string
+	Short    string
+	Op       string // The operation (the name of the environment's "#op" link).
+	Question string // The confirmation question, formatted with the environment ID.
+
+	// Check validates the environment before the operation, returning
+	// skip=true if it does not need to be run.
+	Check func(env *api.Environment) (skip bool, err error)
+}
+
+var (
+	environmentActivate = environmentOperation{
+		Use:      "environment:activate [environment]...",
+		Short:    "Activate an environment",
+		Op:       "activate",
+		Question: "Are you sure you want to activate the environment %s?",
+		Check: func(env *api.Environment) (bool, error) {
+			return env.Status == api.EnvironmentStatusActive, nil
+		},
+	}
+	environmentPause = environmentOperation{
+		Use:      "environment:pause",
+		Short:    "Pause an environment",
+		Op:       "pause",
+		Question: "Are you sure you want to pause the environment %s?",
+		Check: func(env *api.Environment) (bool, error) {
+			if env.Status != api.EnvironmentStatusActive {
+				return false, fmt.Errorf("the environment %s is not active; only active environments can be paused", env.ID)
+			}
+			return false, nil
+		},
+	}
+	environmentResume = environmentOperation{
+		Use:      "environment:resume",
+		Short:    "Resume a paused environment",
+		Op:       "resume",
+		Question: "Are you sure you want to resume the paused environment %s?",
+		Check: func(env *api.Environment) (bool, error) {
+			if env.Status != api.EnvironmentStatusPaused {
+				return false, fmt.Errorf("the environment %s is not paused; only paused environments can be resumed", env.ID)
+			}
+			return false, nil
+		},
+	}
+	environmentRedeploy = environmentOperation{
+		Use:      "environment:redeploy",
+		Aliases:  []string{"redeploy"},
+		Short:    "Redeploy an environment",
+		Op:       "redeploy",
+		Question: "Are you sure you want to redeploy the environment %s?",
+	}
+)
+
+func newEnvironmentOperationCommand(cnf *config.Config, o environmentOperation) *cobra.Command {
+	args := cobra.NoArgs
+	if o.Op == "activate" {
+		args = cobra.ArbitraryArgs
+	}
+	cmd := &cobra.Command{
+		Use:     o.Use,
+		Aliases: o.Aliases,
+		Short:   o.Short,
+		Args:    args,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEnvironmentOperation(cmd, cnf, o, args)
+		},
+	}
+	addSelectionFlags(cmd, true)
+	addWaitFlags(cmd)
+	return cmd
+}
+
+func runEnvironmentOperation(cmd *cobra.Command, cnf *config.Config, o environmentOperation, args []string) error {
+	projectID, err := selectProject(cmd, cnf)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient(cmd, cnf)
+	if err != nil {
+		return err
+	}
+
+	var envs []*api.Environment
+	if len(args) == 0 {
+		env, err := selectEnvironment(cmd, cnf, client, projectID)
+		if err != nil {
+			return err
+		}
+		envs = append(envs, env)
+	}
+	for _, id := range args {
+		env, err := findEnvironment(cmd, client, projectID, id)
+		if err != nil {
+			return err
+		}
+		envs = append(envs, env)
+	}
+
+	stderr := cmd.ErrOrStderr()
+	var activities []*api.Activity
+	for _, env := range envs {
+		if o.Check != nil {
+			skip, err := o.Check(env)
+			if err != nil {
+				return err
+			}
+			if skip {
+				fmt.Fprintf(stderr, "The environment %s is already %s.\n", env.ID, env.Status)
+				continue
+			}
+		}
+		if !confirmAction(fmt.Sprintf(o.Question, env.ID)) {
+			continue
+		}
+		started, err := client.RunEnvironmentOperation(cmd.Context(), env, o.Op)
+		if err != nil {
+			return err
+		}
+		activities = append(activities, started...)
+	}
+
+	if len(activities) > 0 && shouldWait(cmd) {
+		return waitForActivities(cmd, client, activities)
+	}
+	return nil
+}
+
+// confirmAction asks a yes/no question, which defaults to "yes" in non-interactive mode.
+func confirmAction(question string) bool {
+	if !isInteractive() {
+		return true
+	}
+	return terminal.AskConfirmation(question, true)
+}
+
+// isInteractive returns whether the user can be asked questions.
+func isInteractive() bool {
+	return !viper.GetBool("no-interaction") && terminal.Stdin.IsInteractive()
+}
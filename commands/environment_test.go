@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"encoding/json"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initGitRepo creates a Git repository in a temporary working directory,
+// with a project remote and a branch checked out.
+func initGitRepo(t *testing.T, remoteName, projectID, branch string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	for _, args := range [][]string{
+		{"init", "-q", "-b", branch},
+		{"remote", "add", remoteName, projectID + "@git.region-1.example.com:" + projectID + ".git"},
+	} {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+}
+
+func TestEnvironmentList(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetEnvironments(testEnvironments("abc123"))
+
+	out, err := runCommand(t, newEnvironmentListCommand(cnf), "-p", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, `+------------+-------------+----------+-------------+
+| ID         | Title       | Status   | Type        |
++------------+-------------+----------+-------------+
+| main       | Main        | active   | production  |
+|    feature | A feature   | paused   | development |
+|    staging | Staging     | active   | staging     |
+|       dev  | Development | inactive | development |
++------------+-------------+----------+-------------+
+`, out)
+
+	out, err = runCommand(t, newEnvironmentListCommand(cnf), "-p", "abc123", "--pipe", "-I", "--sort", "id")
+	require.NoError(t, err)
+	assert.Equal(t, "feature\nmain\nstaging\n", out)
+
+	out, err = runCommand(t, newEnvironmentListCommand(cnf), "-p", "abc123", "--format", "json",
+		"--status", "active", "--columns", "id,parent")
+	require.NoError(t, err)
+	var rows []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	assert.Equal(t, []map[string]any{
+		{"id": "main", "parent": nil},
+		{"id": "staging", "parent": "main"},
+	}, rows)
+
+	// The project is detected from the Git remote.
+	initGitRepo(t, cnf.Detection.GitRemoteName, "abc123", "staging")
+	out, err = runCommand(t, newEnvironmentListCommand(cnf), "--pipe", "--type", "production")
+	require.NoError(t, err)
+	assert.Equal(t, "main\n", out)
+}
+
+func TestEnvironmentInfo(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetEnvironments(testEnvironments("abc123"))
+
+	out, err := runCommand(t, newEnvironmentInfoCommand(cnf), "-p", "abc123", "-e", "staging", "type")
+	require.NoError(t, err)
+	assert.Equal(t, "staging\n", out)
+
+	// The environment is detected from the Git branch.
+	initGitRepo(t, cnf.Detection.GitRemoteName, "abc123", "feature")
+	out, err = runCommand(t, newEnvironmentInfoCommand(cnf), "--format", "json")
+	require.NoError(t, err)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &props))
+	assert.Equal(t, "feature-abc123", props["machine_name"])
+	assert.NotContains(t, props, "_links")
+
+	_, err = runCommand(t, newEnvironmentInfoCommand(cnf), "-e", "missing")
+	assert.ErrorContains(t, err, "environment not found: missing")
+}
+
+func TestEnvironmentOperations(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetEnvironments(testEnvironments("abc123"))
+	status := func(id string) string {
+		out, err := runCommand(t, newEnvironmentInfoCommand(cnf), "-p", "abc123", "-e", id, "status")
+		require.NoError(t, err)
+		return out
+	}
+
+	_, err := runCommand(t, newEnvironmentOperationCommand(cnf, environmentActivate), "-p", "abc123", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "active\n", status("dev"))
+
+	_, err = runCommand(t, newEnvironmentOperationCommand(cnf, environmentPause), "-p", "abc123", "-e", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "paused\n", status("dev"))
+
+	_, err = runCommand(t, newEnvironmentOperationCommand(cnf, environmentPause), "-p", "abc123", "-e", "dev")
+	assert.ErrorContains(t, err, "only active environments can be paused")
+
+	_, err = runCommand(t, newEnvironmentOperationCommand(cnf, environmentResume),
+		"-p", "abc123", "-e", "dev", "--no-wait")
+	require.NoError(t, err)
+	assert.Equal(t, "active\n", status("dev"))
+
+	_, err = runCommand(t, newEnvironmentOperationCommand(cnf, environmentRedeploy), "-p", "abc123", "-e", "main")
+	require.NoError(t, err)
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/auth"
+	"github.com/platformsh/cli/internal/config"
+)
+
+// Exit codes for classes of errors, which scripts can rely on.
+const (
+	exitCodeGeneral      = 1
+	exitCodeAuthRequired = 3
+	exitCodeForbidden    = 4
+	exitCodeNotFound     = 5
+	exitCodeConflict     = 6
+	exitCodeRateLimited  = 7
+	exitCodeValidation   = 8
+	exitCodeUnavailable  = 9
+)
+
+// exitCodeForError returns the exit code for an error, by its class.
+func exitCodeForError(err error) int {
+	switch {
+	case errors.Is(err, auth.ErrReauthenticationRequired), api.IsUnauthorized(err):
+		return exitCodeAuthRequired
+	case api.IsForbidden(err):
+		return exitCodeForbidden
+	case api.IsNotFound(err):
+		return exitCodeNotFound
+	case api.IsConflict(err):
+		return exitCodeConflict
+	case api.IsRateLimited(err):
+		return exitCodeRateLimited
+	case api.IsValidation(err):
+		return exitCodeValidation
+	case api.IsRetryable(err):
+		return exitCodeUnavailable
+	}
+	var apiErr api.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode() >= 500 {
+		return exitCodeUnavailable
+	}
+	return exitCodeGeneral
+}
+
+// renderError prints a friendly message for an error.
+// In debug mode, the full API request URL and response are printed too.
+func renderError(w io.Writer, cnf *config.Config, err error, debug bool) {
+	executable := cnf.Application.Executable
+
+	var apiErr api.Error
+	if !errors.As(err, &apiErr) {
+		fmt.Fprintln(w, color.RedString(err.Error()))
+		if errors.Is(err, auth.ErrReauthenticationRequired) {
+			fmt.Fprintf(w, "Your session has expired or is no longer valid. Please log in again by running: %s\n",
+				color.YellowString(executable+" login"))
+		}
+		return
+	}
+
+	msg := apiErr.Summary()
+	switch {
+	case apiErr.Original != nil:
+		msg = apiErr.Original.Error()
+	case msg == "" && apiErr.Response != nil:
+		msg = apiErr.Response.Status
+	}
+	fmt.Fprintln(w, color.RedString("API error: %s", msg))
+	for _, line := range apiErr.ValidationMessages() {
+		fmt.Fprintf(w, "  - %s\n", line)
+	}
+
+	switch exitCodeForError(err) {
+	case exitCodeAuthRequired:
+		fmt.Fprintf(w, "Please log in again by running: %s\n", color.YellowString(executable+" login"))
+	case exitCodeForbidden:
+		fmt.Fprintln(w, "You do not have permission to perform this action.")
+	case exitCodeNotFound:
+		fmt.Fprintln(w, "The requested resource was not found, or you do not have access to it.")
+	case exitCodeRateLimited:
+		fmt.Fprintln(w, "Too many requests were made to the API. Please wait and try again.")
+	case exitCodeUnavailable:
+		fmt.Fprintln(w, "The API is temporarily unavailable. Please try again later.")
+	}
+
+	if debug {
+		fmt.Fprintf(w, "\n%s\n", strings.TrimSpace(apiErr.Dump()))
+	} else {
+		fmt.Fprintf(w, "Run with --debug for more details.\n")
+	}
+}
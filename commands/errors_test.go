@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/auth"
+	"github.com/platformsh/cli/internal/config"
+)
+
+func TestExitCodeForError(t *testing.T) {
+	apiErr := func(status int) error {
+		return api.Error{
+			URL:      "https://api.example.com/projects/abc",
+			Response: &http.Response{StatusCode: status, Status: http.StatusText(status)},
+		}
+	}
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{errors.New("other"), exitCodeGeneral},
+		{fmt.Errorf("%w: no refresh token", auth.ErrReauthenticationRequired), exitCodeAuthRequired},
+		{apiErr(http.StatusUnauthorized), exitCodeAuthRequired},
+		{apiErr(http.StatusForbidden), exitCodeForbidden},
+		{fmt.Errorf("wrapped: %w", apiErr(http.StatusNotFound)), exitCodeNotFound},
+		{apiErr(http.StatusConflict), exitCodeConflict},
+		{apiErr(http.StatusTooManyRequests), exitCodeRateLimited},
+		{apiErr(http.StatusBadRequest), exitCodeValidation},
+		{apiErr(http.StatusServiceUnavailable), exitCodeUnavailable},
+		{apiErr(http.StatusInternalServerError), exitCodeUnavailable},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, exitCodeForError(c.err), c.err.Error())
+	}
+}
+
+func TestRenderError(t *testing.T) {
+	err := api.Error{
+		URL: "https://api.example.com/projects",
+		Response: &http.Response{
+			StatusCode: http.StatusBadRequest, Status: "400 Bad Request", ProtoMajor: 1, ProtoMinor: 1,
+			Body: io.NopCloser(strings.NewReader("")),
+		},
+		Message:    "Bad Request",
+		Validation: map[string][]string{"title": {"required"}},
+		RawBody:    []byte(`{"raw": true}`),
+	}
+
+	cnf := &config.Config{}
+	cnf.Application.Executable = "test-cli"
+
+	var buf bytes.Buffer
+	renderError(&buf, cnf, err, false)
+	assert.Contains(t, buf.String(), "API error: Bad Request\n  - title: required\n")
+	assert.Contains(t, buf.String(), "--debug")
+	assert.NotContains(t, buf.String(), `{"raw": true}`)
+
+	buf.Reset()
+	renderError(&buf, cnf, err, true)
+	assert.Contains(t, buf.String(), "URL: https://api.example.com/projects")
+	assert.Contains(t, buf.String(), `{"raw": true}`)
+
+	// Login hints use the configured executable name.
+	buf.Reset()
+	renderError(&buf, cnf, fmt.Errorf("refresh failed: %w", auth.ErrReauthenticationRequired), false)
+	assert.Contains(t, buf.String(), "test-cli login")
+}
@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+// newTestAPI starts mock API and auth servers, and returns a config using them.
+func newTestAPI(t *testing.T) (*config.Config, *mockapi.Handler) {
+	t.Helper()
+	authServer := mockapi.NewAuthServer(t)
+	t.Cleanup(authServer.Close)
+	apiHandler := mockapi.NewHandler(t)
+	apiServer := httptest.NewServer(apiHandler)
+	t.Cleanup(apiServer.Close)
+
+	cnf := &config.Config{}
+	cnf.Application.Name = "Test CLI"
+	cnf.Application.Executable = "test-cli"
+	cnf.Application.EnvPrefix = "TEST_CLI_"
+	cnf.Application.Slug = "test-cli"
+	cnf.Application.WritableUserDir = ".test-cli"
+	cnf.Application.UserStateFile = "state.json"
+	cnf.Service.Name = "Test"
+	cnf.API.BaseURL = apiServer.URL
+	cnf.API.AuthURL = authServer.URL
+	cnf.API.OAuth2TokenURL = authServer.URL + "/oauth2/token"
+	cnf.API.SessionID = "default"
+	cnf.API.EnableOrganizations = true
+	cnf.Detection.GitRemoteName = "test"
+	t.Setenv(cnf.Application.EnvPrefix+"HOME", t.TempDir())
+	t.Setenv(cnf.Application.EnvPrefix+"TMP", t.TempDir())
+	t.Setenv(cnf.Application.EnvPrefix+"TOKEN", mockapi.ValidAPITokens[0])
+	return cnf, apiHandler
+}
+
+// runCommand executes a command with arguments, returning its stdout.
+func runCommand(t *testing.T, cmd *cobra.Command, args ...string) (string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(context.Background())
+	return stdout.String(), err
+}
+
+// testEnvironments returns a project's environments: production, staging and two development environments.
+func testEnvironments(projectID string) []*mockapi.Environment {
+	makeEnv := func(id, title, status, envType string, parent any) *mockapi.Environment {
+		return &mockapi.Environment{
+			ID:          id,
+			Name:        id,
+			MachineName: id + "-abc123",
+			Title:       title,
+			Type:        envType,
+			Status:      status,
+			Parent:      parent,
+			Project:     projectID,
+			Links: mockapi.MakeHALLinks(
+				"self=/projects/"+projectID+"/environments/"+id,
+				"#activate=/projects/"+projectID+"/environments/"+id+"/activate",
+				"#pause=/projects/"+projectID+"/environments/"+id+"/pause",
+				"#resume=/projects/"+projectID+"/environments/"+id+"/resume",
+				"#redeploy=/projects/"+projectID+"/environments/"+id+"/redeploy",
+			),
+		}
+	}
+	return []*mockapi.Environment{
+		makeEnv("main", "Main", "active", "production", nil),
+		makeEnv("staging", "Staging", "active", "staging", "main"),
+		makeEnv("dev", "Development", "inactive", "development", "staging"),
+		makeEnv("feature", "A feature", "paused", "development", "main"),
+	}
+}
+
+// testActivity returns an environment.push activity in the given state.
+func testActivity(projectID, id, state string) *mockapi.Activity {
+	a := &mockapi.Activity{
+		ID:          id,
+		Type:        "environment.push",
+		State:       state,
+		Project:     projectID,
+		Description: "<user>Test User</user> pushed to <environment>main</environment>",
+		CreatedAt:   time.Now().Add(-time.Hour),
+		Links: mockapi.MakeHALLinks(
+			"self=/projects/"+projectID+"/activities/"+id,
+			"log=/projects/"+projectID+"/activities/"+id+"/log",
+		),
+	}
+	return a
+}
+
+// testVariables sets project-level and environment-level variables.
+func testVariables(apiHandler *mockapi.Handler, projectID, environmentID string) {
+	projectVar := func(name, value string, sensitive bool) *mockapi.Variable {
+		return &mockapi.Variable{
+			Name:           name,
+			Value:          value,
+			IsSensitive:    sensitive,
+			VisibleBuild:   true,
+			VisibleRuntime: true,
+			Links: mockapi.MakeHALLinks(
+				"self=/projects/"+projectID+"/variables/"+name,
+				"#edit=/projects/"+projectID+"/variables/"+name,
+				"#delete=/projects/"+projectID+"/variables/"+name,
+			),
+		}
+	}
+	envVar := func(name, value string, inherited bool) *mockapi.EnvLevelVariable {
+		v := projectVar(name, value, false)
+		v.Links = mockapi.MakeHALLinks(
+			"self=/projects/" + projectID + "/environments/" + environmentID + "/variables/" + name,
+		)
+		return &mockapi.EnvLevelVariable{Variable: *v, IsEnabled: true, Inherited: inherited, IsInheritable: true}
+	}
+	apiHandler.SetProjectVariables(projectID, []*mockapi.Variable{
+		projectVar("env:SHARED", "project-value", false),
+		projectVar("env:SECRET", "s3cret", true),
+	})
+	apiHandler.SetEnvLevelVariables(projectID, environmentID, []*mockapi.EnvLevelVariable{
+		envVar("env:SHARED", "env-value", false),
+		envVar("env:FROM_PARENT", "parent-value", true),
+	})
+}
+
+// testOrgs sets the current user, with an organization they own and another they do not.
+func testOrgs(apiHandler *mockapi.Handler) {
+	apiHandler.SetMyUser(&mockapi.User{ID: "my-user-id"})
+	apiHandler.SetOrgs([]*mockapi.Org{
+		{ID: "org-1", Name: "mine", Label: "Mine", Type: "flexible", Owner: "my-user-id",
+			Capabilities: []string{"autoscaling", "metrics"}},
+		{ID: "org-2", Name: "theirs", Label: "Theirs", Type: "fixed", Owner: "other-user-id"},
+	})
+}
+
+// testUserGrants sets projects in the organizations, and the project access of users.
+func testUserGrants(apiHandler *mockapi.Handler) {
+	apiHandler.SetProjects([]*mockapi.Project{
+		{ID: "abc123", Title: "Site", Organization: "org-1"},
+		{ID: "def456", Title: "Shop", Organization: "org-1"},
+		{ID: "ghi789", Title: "Other", Organization: "org-2"},
+	})
+	apiHandler.SetUserGrants([]*mockapi.UserGrant{
+		{ResourceID: "abc123", ResourceType: "project", OrganizationID: "org-1", UserID: "alice",
+			Permissions: []string{"admin"}},
+		{ResourceID: "abc123", ResourceType: "project", OrganizationID: "org-1", UserID: "bob",
+			Permissions: []string{"viewer", "production:viewer", "staging:contributor"}},
+		{ResourceID: "def456", ResourceType: "project", OrganizationID: "org-1", UserID: "bob",
+			Permissions: []string{"viewer", "production:admin"}},
+		{ResourceID: "ghi789", ResourceType: "project", OrganizationID: "org-2", UserID: "bob",
+			Permissions: []string{"admin"}},
+	})
+}
+
+// testRegions returns regions from several providers, one of which is not available.
+func testRegions() []*mockapi.Region {
+	return []*mockapi.Region{
+		{ID: "test-region", Zone: "Europe", Timezone: "Europe/Paris", Available: true,
+			Provider:            mockapi.RegionProvider{Name: "OVHcloud"},
+			Datacenter:          mockapi.RegionDatacenter{Location: "France"},
+			EnvironmentalImpact: &mockapi.RegionEnvironmentalImpact{CarbonIntensity: 56, Green: true}},
+		{ID: "de-region", Zone: "Europe", Timezone: "Europe/Berlin", Available: true,
+			Provider:            mockapi.RegionProvider{Name: "AWS"},
+			Datacenter:          mockapi.RegionDatacenter{Location: "Germany"},
+			EnvironmentalImpact: &mockapi.RegionEnvironmentalImpact{CarbonIntensity: 380}},
+		{ID: "us-region", Zone: "North America", Timezone: "America/New_York", Available: true,
+			Provider:   mockapi.RegionProvider{Name: "AWS"},
+			Datacenter: mockapi.RegionDatacenter{Location: "United States"}},
+		{ID: "old-region", Zone: "Europe", Timezone: "Europe/Dublin", Available: false,
+			Provider: mockapi.RegionProvider{Name: "AWS"}},
+	}
+}
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"bytes"
 	"cmp"
 	"context"
 	"fmt"
@@ -22,12 +21,11 @@ import (
 	"github.com/upsun/whatsun/pkg/files"
 	"gopkg.in/yaml.v3"
 
-	"github.com/platformsh/cli/internal/legacy"
-
 	"github.com/platformsh/cli/internal/api"
 	"github.com/platformsh/cli/internal/auth"
 	"github.com/platformsh/cli/internal/config"
 	_init "github.com/platformsh/cli/internal/init"
+	"github.com/platformsh/cli/internal/selection"
 )
 
 func newInitCommand(cnf *config.Config, assets *vendorization.VendorAssets) *cobra.Command {
@@ -112,29 +110,33 @@ func runInitCommand(
 	// TODO check if this is needed
 	cnf.API.AIServiceURL = cmp.Or(os.Getenv(cnf.Application.EnvPrefix+"API_AI_URL"), cnf.API.AIServiceURL)
 
-	legacyCLIClient, err := auth.NewLegacyCLIClient(cmd.Context(),
+	legacyCLIClient, err := auth.NewLegacyCLIClient(withAPITransport(cmd, cnf),
 		makeLegacyCLIWrapper(cnf, cmd.OutOrStdout(), cmd.ErrOrStderr(), cmd.InOrStdin()))
 	if err != nil {
 		return err
 	}
 
+	var stderr = cmd.ErrOrStderr()
+
 	msg, canUse := canUseAI(cnf)
 	if !canUse {
 		if useAI {
 			return fmt.Errorf("cannot use AI: %s", msg)
 		}
-		return runNonAIConfig()
+		if err := runNonAIConfig(); err != nil {
+			return err
+		}
+		suggestProjectCreation(cmd, cnf, nil, initOptions, stderr)
+		return nil
 	}
 
-	var stderr = cmd.ErrOrStderr()
-
 	fmt.Fprintln(stderr, color.CyanString("Creating %s configuration", cnf.Service.Name))
 	fmt.Fprintln(stderr)
 
 	var isInteractive = !viper.GetBool("no-interaction")
 
 	debugLog("Checking selected organization")
-	org, err := handleOrganizations(cmd.Context(), cnf, legacyCLIClient, initOptions)
+	org, err := handleOrganizations(cmd, cnf, initOptions, gitRoot)
 	if err != nil {
 		return err
 	}
@@ -163,7 +165,11 @@ func runInitCommand(
 	}
 
 	if !useAI {
-		return runNonAIConfig()
+		if err := runNonAIConfig(); err != nil {
+			return err
+		}
+		suggestProjectCreation(cmd, cnf, org, initOptions, stderr)
+		return nil
 	}
 
 	if org != nil && org.Type != api.OrgTypeFlexible {
@@ -190,41 +196,103 @@ func runInitCommand(
 	initOptions.IsDebug = viper.GetBool("debug")
 	initOptions.DebugLogFunc = debugLog
 
-	return _init.RunAIConfig(cmd.Context(), cnf, dg, gitRoot, initOptions, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	if err := _init.RunAIConfig(cmd.Context(), cnf, dg, gitRoot, initOptions, cmd.OutOrStdout(), stderr); err != nil {
+		return err
+	}
+	suggestProjectCreation(cmd, cnf, org, initOptions, stderr)
+	return nil
+}
+
+// suggestProjectCreation explains how to create a project for the new
+// configuration, in a suggested region, if no project is linked yet. If no
+// organization is given, the user's organization is used (see selectOrganization).
+func suggestProjectCreation(
+	cmd *cobra.Command,
+	cnf *config.Config,
+	org *api.Organization,
+	initOptions *_init.Options,
+	stderr io.Writer,
+) {
+	if !cnf.API.EnableOrganizations || initOptions.ProjectID != "" {
+		return
+	}
+	ctx := cmd.Context()
+	apiClient, err := newAPIClient(cmd, cnf)
+	if err != nil {
+		debugLog("Could not suggest a region: %s", err)
+		return
+	}
+	if org == nil {
+		if org, err = selectOrganization(cmd, cnf, apiClient); err != nil {
+			debugLog("Could not suggest a region: %s", err)
+			return
+		}
+	}
+	regions, err := apiClient.ListRegions(ctx)
+	if err != nil {
+		debugLog("Could not list regions: %s", err)
+		return
+	}
+	setupOptions, err := apiClient.GetSetupOptions(ctx, org.ID)
+	if err != nil {
+		debugLog("Could not get the setup options: %s", err)
+		return
+	}
+	r := suggestRegion(regions, setupOptions.Regions, time.Now())
+	if r == nil {
+		return
+	}
+	fmt.Fprintln(stderr)
+	fmt.Fprintf(stderr, "To create a project for this configuration in the suggested region, %s, run:\n",
+		describeRegion(r))
+	fmt.Fprintln(stderr, color.CyanString("  %s project:create --org %s --region %s",
+		cnf.Application.Executable, org.Name, r.ID))
 }
 
-// handleOrganizations manages organization selection and validation.
+// handleOrganizations manages organization selection and validation, using
+// the organization of the current project (if any) in the directory.
 // It modifies initOptions.OrganizationID and initOptions.ProjectID.
 func handleOrganizations(
-	ctx context.Context, cnf *config.Config, legacyCLIClient *auth.LegacyCLIClient, initOptions *_init.Options,
+	cmd *cobra.Command,
+	cnf *config.Config,
+	initOptions *_init.Options,
+	dir string,
 ) (*api.Organization, error) {
 	if !cnf.API.EnableOrganizations {
 		return nil, nil
 	}
 
-	apiClient, err := api.NewClient(cnf.API.BaseURL, legacyCLIClient.HTTPClient)
-	if err != nil {
-		return nil, err
+	ctx := cmd.Context()
+	currentProjectID, _ := newSelectionResolver(cnf).Project(ctx, selection.Input{Dir: dir})
+	if currentProjectID == "" {
+		return nil, nil
 	}
 
-	silentLegacyWrapper := makeLegacyCLIWrapper(cnf, nil, nil, nil)
-	currentOrgID, currentProjectID := getCurrentOrganizationAndProjectID(ctx, silentLegacyWrapper)
-
-	if currentOrgID == "" {
-		return nil, nil
+	apiClient, err := newAPIClient(cmd, cnf)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := legacyCLIClient.EnsureAuthenticated(ctx); err != nil {
+	project, err := apiClient.GetProject(ctx, currentProjectID)
+	if err != nil {
+		// The repository may belong to a project which the user cannot access.
+		if api.IsNotFound(err) || api.IsForbidden(err) {
+			debugLog("The current project is not accessible: %s: %s", currentProjectID, err)
+			return nil, nil
+		}
 		return nil, err
 	}
+	if project.Organization == "" {
+		return nil, nil
+	}
 
-	org, err := apiClient.GetOrganization(ctx, currentOrgID)
+	org, err := apiClient.GetOrganization(ctx, project.Organization)
 	if err != nil {
 		return nil, err
 	}
 
-	initOptions.OrganizationID = currentOrgID
-	initOptions.ProjectID = currentProjectID
+	initOptions.OrganizationID = org.ID
+	initOptions.ProjectID = project.ID
 	return org, nil
 }
 
@@ -285,26 +353,6 @@ func generateDigest(ctx context.Context, path string) (*digest.Digest, error) {
 	return digester.GetDigest(ctx)
 }
 
-func getCurrentOrganizationAndProjectID(ctx context.Context, wrapper *legacy.CLIWrapper) (orgID, projectID string) {
-	var buf = &bytes.Buffer{}
-	wrapper.Stderr = nil
-	wrapper.Stdout = buf
-	wrapper.Stdin = nil
-
-	if err := wrapper.Exec(ctx, "project:info", "id", "--no-interaction"); err != nil {
-		return
-	}
-	projectID = strings.TrimSpace(buf.String())
-	buf.Reset()
-
-	if err := wrapper.Exec(ctx, "organization:info", "id", "--no-interaction"); err != nil {
-		return
-	}
-	orgID = strings.TrimSpace(buf.String())
-
-	return
-}
-
 // choose asks the user to select between options.
 // TODO refactor this to a shared internal package
 func choose(stderr io.Writer, message string, options []string) (result string, err error) {
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/platformsh/platformify/vendorization"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+func TestInitSuggestsRegion(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetMyUser(&mockapi.User{ID: "my-user-id"})
+	apiHandler.SetOrgs([]*mockapi.Org{
+		{ID: "org-1", Name: "mine", Label: "Mine", Type: "flexible", Owner: "my-user-id"},
+	})
+	apiHandler.SetRegions(testRegions())
+
+	aiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/ai/generate-configuration" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = w.Write([]byte(`{"type":"data","key":"output","data":{"config_yaml":"applications: {}","valid":true}}
+{"type":"done"}
+`))
+	}))
+	t.Cleanup(aiServer.Close)
+	cnf.API.AIServiceURL = aiServer.URL
+
+	viper.Set("no-interaction", true)
+	t.Cleanup(func() { viper.Set("no-interaction", false) })
+
+	// A repository which is not linked to a project.
+	t.Chdir(t.TempDir())
+	out, err := exec.Command("git", "init", "-q").CombinedOutput()
+	require.NoError(t, err, string(out))
+	require.NoError(t, os.WriteFile("index.php", []byte("<?php echo 'Hello';\n"), 0o600))
+
+	cmd := newInitCommand(cnf, &vendorization.VendorAssets{})
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"--ai"})
+	require.NoError(t, cmd.ExecuteContext(config.ToContext(context.Background(), cnf)), stderr.String())
+
+	assert.Contains(t, stderr.String(), "To create a project for this configuration in the suggested region")
+	assert.Contains(t, stderr.String(), "test-cli project:create --org mine --region test-region")
+}
@@ -29,12 +29,12 @@ func newListCommand(cnf *config.Config) *cobra.Command {
 			c := makeLegacyCLIWrapper(cnf, &b, cmd.ErrOrStderr(), cmd.InOrStdin())
 
 			if err := c.Exec(cmd.Context(), arguments...); err != nil {
-				exitWithError(err)
+				exitWithError(cnf, err)
 			}
 
 			var list List
 			if err := json.Unmarshal(b.Bytes(), &list); err != nil {
-				exitWithError(err)
+				exitWithError(cnf, err)
 			}
 
 			// Override the application name and executable with our own config.
@@ -60,6 +60,8 @@ func newListCommand(cnf *config.Config) *cobra.Command {
 				list.AddCommand(&appProjectConvertCommand)
 			}
 
+			newCommandRouter(cnf, nativeCommandRegistry).mergeInto(&list)
+
 			format := viper.GetString("format")
 			raw := viper.GetBool("raw")
 
@@ -79,14 +81,14 @@ func newListCommand(cnf *config.Config) *cobra.Command {
 				c.Stdout = cmd.OutOrStdout()
 				arguments := []string{"list", "--format=" + format}
 				if err := c.Exec(cmd.Context(), arguments...); err != nil {
-					exitWithError(err)
+					exitWithError(cnf, err)
 				}
 				return
 			}
 
 			result, err := formatter.Format(&list, config.FromContext(cmd.Context()))
 			if err != nil {
-				exitWithError(err)
+				exitWithError(cnf, err)
 			}
 
 			fmt.Fprintln(cmd.OutOrStdout(), string(result))
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"text/tabwriter"
@@ -433,15 +434,43 @@ func (l *List) DescribesNamespace() bool {
 	return l.Namespace != ""
 }
 
+// RemoveCommands removes the commands with any of the given names from the
+// list, and removes the names from the other commands' aliases.
+func (l *List) RemoveCommands(names []string) {
+	var removed []string
+	l.Commands = slices.DeleteFunc(l.Commands, func(c *Command) bool {
+		if slices.Contains(names, c.Name.String()) {
+			removed = append(removed, c.Name.String())
+			return true
+		}
+		return false
+	})
+	for _, c := range l.Commands {
+		c.Aliases = slices.DeleteFunc(slices.Clone(c.Aliases), func(a string) bool {
+			return slices.Contains(names, a)
+		})
+	}
+	for i := range l.Namespaces {
+		ns := &l.Namespaces[i]
+		ns.Commands = slices.DeleteFunc(ns.Commands, func(name string) bool {
+			return slices.Contains(removed, name)
+		})
+	}
+}
+
+// AddCommand adds a command to the list, replacing any existing command of the same name.
 func (l *List) AddCommand(cmd *Command) {
 	for i := range l.Namespaces {
 		name := &l.Namespaces[i]
-		if name.ID == cmd.Name.Namespace {
+		if name.ID == cmd.Name.Namespace && !slices.Contains(name.Commands, cmd.Name.String()) {
 			name.Commands = append(name.Commands, cmd.Name.String())
 			sort.Strings(name.Commands)
 		}
 	}
 
+	l.Commands = slices.DeleteFunc(l.Commands, func(c *Command) bool {
+		return c.Name.String() == cmd.Name.String()
+	})
 	l.Commands = append(l.Commands, cmd)
 	sort.Slice(l.Commands, func(i, j int) bool {
 		switch {
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+
+	"github.com/platformsh/cli/internal/config"
+)
+
+// nativeCommands returns the Go-native commands which are enabled, i.e. not
+// switched back to the legacy CLI.
+func nativeCommands(cnf *config.Config) []*cobra.Command {
+	return newCommandRouter(cnf, nativeCommandRegistry).commands()
+}
+
+// nativeCommandRegistry returns all the Go-native commands, with the legacy
+// CLI commands they replace. It creates new commands on each call (see
+// newCommandRouter).
+func nativeCommandRegistry(cnf *config.Config) []nativeCommand {
+	cmds := []nativeCommand{
+		{Command: newProjectListCommand(cnf), Replaces: []string{"project:list", "projects", "pro"},
+			ReadOnly: readOnly},
+		{Command: newProjectInfoCommand(cnf), Replaces: []string{"project:info"}, ReadOnly: readOnlyWithoutValue},
+		{Command: newEnvironmentListCommand(cnf), Replaces: []string{"environment:list", "environments", "env"},
+			ReadOnly: readOnly},
+		{Command: newEnvironmentInfoCommand(cnf), Replaces: []string{"environment:info"},
+			ReadOnly: readOnlyWithoutValue},
+		{Command: newEnvironmentOperationCommand(cnf, environmentActivate), Replaces: []string{"environment:activate"}},
+		{Command: newEnvironmentOperationCommand(cnf, environmentPause), Replaces: []string{"environment:pause"}},
+		{Command: newEnvironmentOperationCommand(cnf, environmentResume), Replaces: []string{"environment:resume"}},
+		{Command: newEnvironmentOperationCommand(cnf, environmentRedeploy),
+			Replaces: []string{"environment:redeploy", "redeploy"}},
+		{Command: newVariableListCommand(cnf), Replaces: []string{"variable:list", "variables", "var"},
+			ReadOnly: readOnly},
+		{Command: newVariableGetCommand(cnf), Replaces: []string{"variable:get", "vget"}, ReadOnly: readOnly},
+		{Command: newVariableCreateCommand(cnf), Replaces: []string{"variable:create"}},
+		{Command: newVariableUpdateCommand(cnf), Replaces: []string{"variable:update"}},
+		{Command: newVariableDeleteCommand(cnf), Replaces: []string{"variable:delete"}},
+		{Command: newVariableImportCommand(cnf)},
+		{Command: newBackupCreateCommand(cnf), Replaces: []string{"backup:create", "backup"}},
+		{Command: newBackupListCommand(cnf), Replaces: []string{"backup:list", "backups"}, ReadOnly: readOnly,
+			NativeFlags: []string{"older-than", "newer-than", "keep", "keep-age", "outside-retention"}},
+		{Command: newBackupGetCommand(cnf), Replaces: []string{"backup:get"}, ReadOnly: readOnly},
+		{Command: newBackupRestoreCommand(cnf), Replaces: []string{"backup:restore"}},
+		{Command: newRegionListCommand(cnf)},
+	}
+	if cnf.API.EnableOrganizations {
+		cmds = append(cmds,
+			nativeCommand{Command: newOrganizationListCommand(cnf),
+				Replaces: []string{"organization:list", "orgs", "organizations"}, ReadOnly: readOnly},
+			nativeCommand{Command: newOrganizationInfoCommand(cnf), Replaces: []string{"organization:info"},
+				ReadOnly: readOnlyWithoutValue},
+			nativeCommand{Command: newOrganizationBillingCommand(cnf)},
+			nativeCommand{Command: newOrganizationUserListCommand(cnf),
+				Replaces: []string{"organization:user:list", "organization:users"}, ReadOnly: readOnly},
+			nativeCommand{Command: newOrganizationUserAddCommand(cnf), Replaces: []string{"organization:user:add"}},
+			nativeCommand{Command: newOrganizationUserUpdateCommand(cnf), Replaces: []string{"organization:user:update"}},
+			nativeCommand{Command: newOrganizationUserRemoveCommand(cnf), Replaces: []string{"organization:user:delete"}},
+			nativeCommand{Command: newProjectCreateCommand(cnf), Replaces: []string{"project:create", "create"}},
+			nativeCommand{Command: newUserListCommand(cnf), Replaces: []string{"user:list", "users"}, ReadOnly: readOnly},
+			nativeCommand{Command: newUserAddCommand(cnf), Replaces: []string{"user:add"}},
+			nativeCommand{Command: newUserUpdateCommand(cnf), Replaces: []string{"user:update"}},
+			nativeCommand{Command: newUserDeleteCommand(cnf), Replaces: []string{"user:delete"}},
+			nativeCommand{Command: newUserAuditCommand(cnf)},
+		)
+	}
+	for _, n := range cmds {
+		n.SetHelpFunc(func(cmd *cobra.Command, _ []string) {
+			internalCmd := innerNativeCommand(cnf, cmd)
+			fmt.Fprintln(cmd.OutOrStdout(), internalCmd.HelpPage(cnf))
+		})
+	}
+	return cmds
+}
+
+// innerNativeCommand describes a native command in the same format as the legacy CLI's
+// commands, so that it can be merged into the "list" and "help" output.
+func innerNativeCommand(cnf *config.Config, cmd *cobra.Command) Command {
+	noInteractionOption := NoInteractionOption(cnf)
+
+	fields := strings.Fields(cmd.Use)
+	var name CommandName
+	if ns, c, ok := strings.Cut(fields[0], ":"); ok {
+		name = CommandName{Namespace: ns, Command: c}
+	} else {
+		name = CommandName{Command: ns}
+	}
+
+	arguments := orderedmap.New[string, Argument]()
+	for _, f := range fields[1:] {
+		argName := strings.Trim(f, "[].")
+		arguments.Set(argName, Argument{
+			Name:       argName,
+			IsRequired: YesNo(!strings.HasPrefix(f, "[")),
+			IsArray:    YesNo(strings.HasSuffix(f, "...")),
+			Default:    Any{},
+		})
+	}
+
+	options := orderedmap.New[string, Option]()
+	cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Hidden {
+			return
+		}
+		opt := Option{
+			Name:        "--" + f.Name,
+			Description: CleanString(f.Usage),
+		}
+		if f.Shorthand != "" {
+			opt.Shortcut = "-" + f.Shorthand
+		}
+		switch t := f.Value.Type(); {
+		case t == "bool":
+			opt.Default = Any{f.DefValue == "true"}
+		case strings.HasSuffix(t, "Slice"):
+			opt.AcceptValue, opt.IsValueRequired, opt.IsMultiple = true, true, true
+			opt.Default = Any{[]string{}}
+		default:
+			opt.AcceptValue, opt.IsValueRequired = true, true
+			opt.Default = Any{f.DefValue}
+		}
+		options.Set(opt.GetName(), opt)
+	})
+	for _, opt := range []Option{HelpOption, VerboseOption, VersionOption, YesOption, noInteractionOption} {
+		options.Set(opt.GetName(), opt)
+	}
+
+	return Command{
+		Name:        name,
+		Usage:       []string{cnf.Application.Executable + " " + cmd.Use},
+		Aliases:     cmd.Aliases,
+		Description: CleanString(cmd.Short),
+		Help:        CleanString(cmd.Long),
+		Examples:    []Example{},
+		Definition: Definition{
+			Arguments: arguments,
+			Options:   options,
+		},
+		Hidden: cmd.Hidden,
+	}
+}
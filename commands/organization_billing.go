@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+var subscriptionColumns = []tableColumn{
+	{Name: "id", Header: "Subscription ID"},
+	{Name: "project_id", Header: "Project ID"},
+	{Name: "project_title", Header: "Project title"},
+	{Name: "project_region", Header: "Region"},
+	{Name: "plan", Header: "Plan"},
+	{Name: "status", Header: "Status"},
+}
+
+// orgTypeDescriptions explain the organization types.
+var orgTypeDescriptions = map[string]string{
+	api.OrgTypeFlexible: "resources are configured per project, and billed according to usage",
+	api.OrgTypeFixed:    "each project has a fixed plan, which determines its resources",
+}
+
+func newOrganizationBillingCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "organization:billing",
+		Short: "View a billing summary of an organization: its type, capabilities and subscriptions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			org, err := selectOrganization(cmd, cnf, client)
+			if err != nil {
+				return err
+			}
+			subs, err := client.ListOrganizationSubscriptions(cmd.Context(), org.ID)
+			if err != nil {
+				return err
+			}
+			canCreate, err := client.CanCreateSubscription(cmd.Context(), org.ID)
+			if err != nil {
+				return err
+			}
+
+			rows := make([]map[string]any, 0, len(subs))
+			for _, s := range subs {
+				rows = append(rows, map[string]any{
+					"id":             s.ID,
+					"project_id":     s.ProjectID,
+					"project_title":  s.ProjectTitle,
+					"project_region": s.ProjectRegion,
+					"plan":           s.Plan,
+					"status":         s.Status,
+				})
+			}
+
+			format, _ := cmd.Flags().GetString("format")
+			if format == "json" {
+				capabilities := org.Capabilities
+				if capabilities == nil {
+					capabilities = []string{}
+				}
+				return writeJSON(cmd.OutOrStdout(), map[string]any{
+					"organization": map[string]any{
+						"id": org.ID, "name": org.Name, "label": org.Label, "type": org.Type,
+					},
+					"capabilities":  capabilities,
+					"can_create":    canCreate,
+					"subscriptions": rows,
+				})
+			}
+
+			// The summary is printed to stderr, so that stdout only contains the table.
+			w := cmd.ErrOrStderr()
+			fmt.Fprintf(w, "Organization: %s (%s)\n", org.Label, org.Name)
+			if desc, ok := orgTypeDescriptions[org.Type]; ok {
+				fmt.Fprintf(w, "Type: %s (%s)\n", org.Type, desc)
+			} else {
+				fmt.Fprintf(w, "Type: %s\n", org.Type)
+			}
+			if len(org.Capabilities) == 0 {
+				fmt.Fprintln(w, "Capabilities: none")
+			} else {
+				fmt.Fprintf(w, "Capabilities: %s\n", strings.Join(org.Capabilities, ", "))
+			}
+			fmt.Fprintf(w, "Can create projects: %s\n", describeCanCreate(canCreate))
+			fmt.Fprintln(w)
+			if len(rows) == 0 {
+				fmt.Fprintln(w, "No subscriptions found.")
+				return nil
+			}
+			fmt.Fprintf(w, "Subscriptions (%d):\n", len(rows))
+			t := &table{Columns: subscriptionColumns, Rows: rows,
+				DefaultColumns: []string{"id", "project_id", "project_title", "plan", "status"}}
+			sortRows(t.Rows, "project_title", false)
+			return t.render(cmd)
+		},
+	}
+	addOrgFlag(cmd)
+	addTableFlags(cmd, subscriptionColumns)
+	return cmd
+}
+
+// describeCanCreate describes whether a project can be created, and what to do if not.
+func describeCanCreate(r *api.CanCreateResult) string {
+	if r.CanCreate {
+		return "yes"
+	}
+	s := "no"
+	if r.Message != "" {
+		s += ": " + r.Message
+	}
+	if r.RequiredAction != nil {
+		s += fmt.Sprintf(" (required action: %s)", r.RequiredAction.Action)
+	}
+	return s
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+var organizationListColumns = []tableColumn{
+	{Name: "id", Header: "ID"},
+	{Name: "name", Header: "Name"},
+	{Name: "label", Header: "Label"},
+	{Name: "type", Header: "Type"},
+	{Name: "owner_id", Header: "Owner ID"},
+	{Name: "capabilities", Header: "Capabilities"},
+	{Name: "status", Header: "Status"},
+	{Name: "created_at", Header: "Created"},
+}
+
+func newOrganizationListCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "organization:list",
+		Aliases: []string{"orgs", "organizations"},
+		Short:   "List organizations",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			me, err := client.GetMyUser(cmd.Context())
+			if err != nil {
+				return err
+			}
+			orgs, err := client.ListUserOrganizations(cmd.Context(), me.ID)
+			if err != nil {
+				return err
+			}
+			if my, _ := cmd.Flags().GetBool("my"); my {
+				orgs = slices.DeleteFunc(orgs, func(o *api.Organization) bool { return o.OwnerID != me.ID })
+			}
+
+			stderr := cmd.ErrOrStderr()
+			if len(orgs) == 0 {
+				fmt.Fprintln(stderr, "No organizations found.")
+				return nil
+			}
+			t := &table{Columns: organizationListColumns, DefaultColumns: []string{"name", "label", "type", "owner_id"}}
+			for _, o := range orgs {
+				t.Rows = append(t.Rows, map[string]any{
+					"id":           o.ID,
+					"name":         o.Name,
+					"label":        o.Label,
+					"type":         o.Type,
+					"owner_id":     o.OwnerID,
+					"capabilities": o.Capabilities,
+					"status":       o.Status,
+					"created_at":   o.CreatedAt,
+				})
+			}
+			sortField, _ := cmd.Flags().GetString("sort")
+			reverse, _ := cmd.Flags().GetBool("reverse")
+			sortRows(t.Rows, sortField, reverse)
+			return t.render(cmd)
+		},
+	}
+	cmd.Flags().Bool("my", false, "List only the organizations you own")
+	cmd.Flags().String("sort", "name", "A property to sort by")
+	cmd.Flags().Bool("reverse", false, "Sort in reverse (descending) order")
+	addTableFlags(cmd, organizationListColumns)
+	return cmd
+}
+
+func newOrganizationInfoCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "organization:info [property] [value]",
+		Short: "View or change organization details",
+		Args:  cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				// Setting properties is handled by the legacy CLI.
+				return makeLegacyCLIWrapper(cnf, cmd.OutOrStdout(), cmd.ErrOrStderr(), cmd.InOrStdin()).
+					Exec(cmd.Context(), removeWrapperFlags(os.Args[1:])...)
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			org, err := selectOrganization(cmd, cnf, client)
+			if err != nil {
+				return err
+			}
+			props, err := client.GetOrganizationProperties(cmd.Context(), org.ID)
+			if err != nil {
+				return err
+			}
+			var property string
+			if len(args) > 0 {
+				property = args[0]
+			}
+			return renderProperties(cmd, props, property)
+		},
+	}
+	addOrgFlag(cmd)
+	addPropertyFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+func TestOrganizationList(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	testOrgs(apiHandler)
+
+	out, err := runCommand(t, newOrganizationListCommand(cnf), "--format", "csv",
+		"--columns", "name,type,capabilities")
+	require.NoError(t, err)
+	assert.Equal(t, "Name,Type,Capabilities\nmine,flexible,\"autoscaling, metrics\"\ntheirs,fixed,\n", out)
+
+	out, err = runCommand(t, newOrganizationListCommand(cnf), "--my", "--format", "csv", "--columns", "id")
+	require.NoError(t, err)
+	assert.Equal(t, "ID\norg-1\n", out)
+
+	// Organizations can be selected by name or ID.
+	out, err = runCommand(t, newOrganizationInfoCommand(cnf), "--org", "theirs", "label")
+	require.NoError(t, err)
+	assert.Equal(t, "Theirs\n", out)
+	out, err = runCommand(t, newOrganizationInfoCommand(cnf), "-o", "org-1", "type")
+	require.NoError(t, err)
+	assert.Equal(t, "flexible\n", out)
+
+	_, err = runCommand(t, newOrganizationInfoCommand(cnf))
+	assert.EqualError(t, err, "could not determine the organization: specify it using --org")
+	_, err = runCommand(t, newOrganizationInfoCommand(cnf), "-o", "missing")
+	assert.EqualError(t, err, "organization not found: missing")
+}
+
+func TestOrganizationBilling(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	testOrgs(apiHandler)
+	apiHandler.SetSubscriptions([]*mockapi.Subscription{
+		{ID: "1", OrganizationID: "org-1", ProjectID: "abc123", ProjectTitle: "Site", Plan: "flexible",
+			Status: "active"},
+		{ID: "2", OrganizationID: "org-2", ProjectID: "def456", ProjectTitle: "Other", Plan: "development",
+			Status: "active"},
+	})
+	apiHandler.SetCanCreate("org-2", &mockapi.CanCreateResponse{
+		Message:        "Billing details are required",
+		RequiredAction: &mockapi.CanCreateRequiredAction{Action: "billing_details", Type: "billing_details"},
+	})
+
+	var stdout, stderr bytes.Buffer
+	cmd := newOrganizationBillingCommand(cnf)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"-o", "mine", "--format", "csv"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, `Organization: Mine (mine)
+Type: flexible (resources are configured per project, and billed according to usage)
+Capabilities: autoscaling, metrics
+Can create projects: yes
+
+Subscriptions (1):
+`, stderr.String())
+	assert.Equal(t, "Subscription ID,Project ID,Project title,Plan,Status\n1,abc123,Site,flexible,active\n",
+		stdout.String())
+
+	out, err := runCommand(t, newOrganizationBillingCommand(cnf), "-o", "theirs", "--format", "json")
+	require.NoError(t, err)
+	var summary map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &summary))
+	assert.Equal(t, []any{}, summary["capabilities"])
+	assert.Equal(t, "fixed", summary["organization"].(map[string]any)["type"])
+	assert.Equal(t, false, summary["can_create"].(map[string]any)["can_create"])
+	assert.Len(t, summary["subscriptions"], 1)
+}
+
+func TestOrganizationUsers(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	testOrgs(apiHandler)
+	apiHandler.SetOrgMembers("org-1", []*mockapi.OrgMember{
+		{ID: "m1", OrganizationID: "org-1", UserID: "my-user-id", Owner: true, Permissions: []string{"admin"}},
+		{ID: "m2", OrganizationID: "org-1", UserID: "alice", Permissions: []string{"projects:list"}},
+	})
+	list := func() string {
+		out, err := runCommand(t, newOrganizationUserListCommand(cnf), "-o", "mine", "--format", "csv")
+		require.NoError(t, err)
+		return out
+	}
+
+	assert.Equal(t, `Email address,Owner,Permissions
+alice@example.com,false,projects:list
+my-user-id@example.com,true,admin
+`, list())
+
+	_, err := runCommand(t, newOrganizationUserUpdateCommand(cnf), "-o", "mine", "alice@example.com",
+		"--permission", "billing,projects:create")
+	require.NoError(t, err)
+	assert.Contains(t, list(), "alice@example.com,false,\"billing, projects:create\"\n")
+
+	_, err = runCommand(t, newOrganizationUserUpdateCommand(cnf), "-o", "mine", "alice", "--permission", "root")
+	assert.ErrorContains(t, err, "invalid permission: root")
+
+	_, err = runCommand(t, newOrganizationUserRemoveCommand(cnf), "-o", "mine", "my-user-id@example.com")
+	assert.EqualError(t, err, "the organization owner cannot be removed")
+
+	_, err = runCommand(t, newOrganizationUserRemoveCommand(cnf), "-o", "mine", "alice")
+	require.NoError(t, err)
+	assert.NotContains(t, list(), "alice")
+
+	_, err = runCommand(t, newOrganizationUserAddCommand(cnf), "-o", "mine", "bob@example.com",
+		"--permission", "projects:list")
+	require.NoError(t, err)
+	invitations := apiHandler.OrgInvitations("org-1")
+	require.Len(t, invitations, 1)
+	assert.Equal(t, "bob@example.com", invitations[0].Email)
+	assert.Equal(t, []string{"projects:list"}, invitations[0].Permissions)
+
+	_, err = runCommand(t, newOrganizationUserAddCommand(cnf), "-o", "mine", "my-user-id@example.com")
+	assert.EqualError(t, err, "the user my-user-id@example.com is already in the organization mine")
+}
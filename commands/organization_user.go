@@ -0,0 +1,223 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+var organizationUserColumns = []tableColumn{
+	{Name: "id", Header: "User ID"},
+	{Name: "email", Header: "Email address"},
+	{Name: "username", Header: "Username"},
+	{Name: "name", Header: "Name"},
+	{Name: "owner", Header: "Owner"},
+	{Name: "permissions", Header: "Permissions"},
+	{Name: "created_at", Header: "Created"},
+	{Name: "updated_at", Header: "Updated"},
+}
+
+func newOrganizationUserListCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "organization:user:list",
+		Aliases: []string{"organization:users"},
+		Short:   "List organization users",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			org, err := selectOrganization(cmd, cnf, client)
+			if err != nil {
+				return err
+			}
+			members, err := client.ListOrganizationMembers(cmd.Context(), org.ID)
+			if err != nil {
+				return err
+			}
+			t := &table{Columns: organizationUserColumns, DefaultColumns: []string{"email", "owner", "permissions"}}
+			for _, m := range members {
+				row := map[string]any{
+					"id":          m.UserID,
+					"owner":       m.Owner,
+					"permissions": m.Permissions,
+					"created_at":  m.CreatedAt,
+					"updated_at":  m.UpdatedAt,
+				}
+				if m.User != nil {
+					row["email"] = m.User.Email
+					row["username"] = m.User.Username
+					row["name"] = strings.TrimSpace(m.User.FirstName + " " + m.User.LastName)
+				}
+				t.Rows = append(t.Rows, row)
+			}
+			sortRows(t.Rows, "email", false)
+
+			format, _ := cmd.Flags().GetString("format")
+			if format == "table" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Users in the organization %s:\n", org.Name)
+			}
+			return t.render(cmd)
+		},
+	}
+	addOrgFlag(cmd)
+	addTableFlags(cmd, organizationUserColumns)
+	return cmd
+}
+
+// addOrgPermissionFlag adds the --permission flag to a command.
+func addOrgPermissionFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("permission", nil,
+		"Permission(s) for the user in the organization. Possible values: "+strings.Join(api.OrgPermissions, ", "))
+}
+
+// orgPermissionFlag reads and validates the --permission flag.
+func orgPermissionFlag(cmd *cobra.Command) ([]string, error) {
+	permissions, _ := cmd.Flags().GetStringSlice("permission")
+	for _, p := range permissions {
+		if !slices.Contains(api.OrgPermissions, p) {
+			return nil, fmt.Errorf("invalid permission: %s (possible values: %s)",
+				p, strings.Join(api.OrgPermissions, ", "))
+		}
+	}
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return permissions, nil
+}
+
+// findOrgMember finds an organization member by email address or user ID.
+func findOrgMember(cmd *cobra.Command, client *api.Client, org *api.Organization, emailOrID string) (
+	*api.OrganizationMember, error) {
+	members, err := client.ListOrganizationMembers(cmd.Context(), org.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		if m.UserID == emailOrID || (m.User != nil && strings.EqualFold(m.User.Email, emailOrID)) {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found in the organization %s: %s", org.Name, emailOrID)
+}
+
+func newOrganizationUserAddCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "organization:user:add [email]",
+		Short: "Invite a user to an organization",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			email := args[0]
+			if !strings.Contains(email, "@") {
+				return fmt.Errorf("invalid email address: %s", email)
+			}
+			permissions, err := orgPermissionFlag(cmd)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			org, err := selectOrganization(cmd, cnf, client)
+			if err != nil {
+				return err
+			}
+			if _, err := client.InviteOrganizationMember(cmd.Context(), org.ID, email, permissions); err != nil {
+				if api.IsConflict(err) {
+					return fmt.Errorf("the user %s is already in the organization %s", email, org.Name)
+				}
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Invited %s to the organization %s\n", email, org.Name)
+			return nil
+		},
+	}
+	addOrgFlag(cmd)
+	addOrgPermissionFlag(cmd)
+	return cmd
+}
+
+func newOrganizationUserUpdateCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "organization:user:update [email]",
+		Short: "Update an organization user's permissions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("permission") {
+				return errors.New("no changes were specified: use --permission to set the user's permissions")
+			}
+			permissions, err := orgPermissionFlag(cmd)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			org, err := selectOrganization(cmd, cnf, client)
+			if err != nil {
+				return err
+			}
+			m, err := findOrgMember(cmd, client, org, args[0])
+			if err != nil {
+				return err
+			}
+			if m.Owner {
+				return errors.New("the organization owner's permissions cannot be changed")
+			}
+			if _, err := client.UpdateOrganizationMember(cmd.Context(), org.ID, m.UserID, permissions); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Updated the permissions of %s in the organization %s\n", args[0], org.Name)
+			return nil
+		},
+	}
+	addOrgFlag(cmd)
+	addOrgPermissionFlag(cmd)
+	return cmd
+}
+
+func newOrganizationUserRemoveCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "organization:user:remove [email]",
+		Aliases: []string{"organization:user:delete"},
+		Short:   "Remove a user from an organization",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			org, err := selectOrganization(cmd, cnf, client)
+			if err != nil {
+				return err
+			}
+			m, err := findOrgMember(cmd, client, org, args[0])
+			if err != nil {
+				return err
+			}
+			if m.Owner {
+				return errors.New("the organization owner cannot be removed")
+			}
+			if !confirmAction(fmt.Sprintf("Are you sure you want to remove %s from the organization %s?",
+				args[0], org.Name)) {
+				return nil
+			}
+			if err := client.RemoveOrganizationMember(cmd.Context(), org.ID, m.UserID); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Removed %s from the organization %s\n", args[0], org.Name)
+			return nil
+		},
+	}
+	addOrgFlag(cmd)
+	return cmd
+}
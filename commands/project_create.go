@@ -0,0 +1,306 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/git"
+	_init "github.com/platformsh/cli/internal/init"
+)
+
+// subscriptionPollInterval is how often a new subscription is polled while its project is provisioned.
+var subscriptionPollInterval = 2 * time.Second
+
+func newProjectCreateCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "project:create",
+		Aliases: []string{"create"},
+		Short:   "Create a new project",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runProjectCreate(cmd, cnf)
+		},
+	}
+	addOrgFlag(cmd)
+	cmd.Flags().String("title", "", "The initial project title")
+	cmd.Flags().String("region", "", "The region where the project will be hosted")
+	cmd.Flags().String("plan", "", "The subscription plan")
+	cmd.Flags().Int("environments", 0, "The number of environments (0 for the plan's default)")
+	cmd.Flags().Int("storage", 0, "The amount of storage per environment, in GiB (0 for the plan's default)")
+	cmd.Flags().String("default-branch", "main", "The default Git branch name for the project")
+	cmd.Flags().Bool("set-remote", false,
+		"Set the new project as the Git remote for the repository in the current directory")
+	addWaitFlags(cmd)
+	return cmd
+}
+
+func runProjectCreate(cmd *cobra.Command, cnf *config.Config) error {
+	client, err := newAPIClient(cmd, cnf)
+	if err != nil {
+		return err
+	}
+	org, err := selectOrganization(cmd, cnf, client)
+	if err != nil {
+		return err
+	}
+	if err := checkCanCreate(cmd, cnf, client, org); err != nil {
+		return err
+	}
+	setupOptions, err := client.GetSetupOptions(cmd.Context(), org.ID)
+	if err != nil {
+		return err
+	}
+
+	stderr := cmd.ErrOrStderr()
+	flags := cmd.Flags()
+	opts := api.SubscriptionOptions{}
+	opts.DefaultBranch, _ = flags.GetString("default-branch")
+	opts.Environments, _ = flags.GetInt("environments")
+	opts.Storage, _ = flags.GetInt("storage")
+	if opts.Title, err = askProjectOption(cmd, "title", "Project title", []string{"Untitled Project"}, true); err != nil {
+		return err
+	}
+	regions := setupOptions.Regions
+	if !flags.Changed("region") && len(regions) > 1 {
+		regions = suggestedRegionFirst(cmd, client, regions)
+	}
+	if opts.Region, err = askProjectOption(cmd, "region", "Region", regions, false); err != nil {
+		return err
+	}
+	if opts.Plan, err = askProjectOption(cmd, "plan", "Plan", setupOptions.Plans, false); err != nil {
+		return err
+	}
+
+	estimate, err := client.EstimateSubscription(cmd.Context(), org.ID, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "Organization: %s (%s)\n", org.Label, org.Name)
+	fmt.Fprintf(stderr, "Title: %s\nRegion: %s\n", opts.Title, opts.Region)
+	if opts.Plan != "" {
+		fmt.Fprintf(stderr, "Plan: %s\n", opts.Plan)
+	}
+	fmt.Fprintf(stderr, "Estimated monthly cost: %s\n", color.YellowString(estimate.Total))
+	if !confirmAction("Are you sure you want to create the project?") {
+		return nil
+	}
+
+	sub, err := client.CreateSubscription(cmd.Context(), org.ID, opts)
+	if err != nil {
+		return err
+	}
+	if sub.OrganizationID == "" {
+		sub.OrganizationID = org.ID
+	}
+	fmt.Fprintf(stderr, "The project is being created (subscription ID: %s).\n", sub.ID)
+	if !shouldWait(cmd) {
+		return nil
+	}
+
+	sub, err = waitForSubscription(cmd, client, sub)
+	if err != nil {
+		return err
+	}
+	project, err := client.GetProject(cmd.Context(), sub.ProjectID)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "The project is now ready: %s\n", color.GreenString(project.ID))
+	fmt.Fprintf(stderr, "  Region: %s\n", sub.ProjectRegion)
+	fmt.Fprintf(stderr, "  Git URL: %s\n", project.Repository.URL)
+	if sub.ProjectUI != "" {
+		fmt.Fprintf(stderr, "  Console URL: %s\n", sub.ProjectUI)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), project.ID)
+
+	return setProjectRemote(cmd, cnf, project)
+}
+
+// askProjectOption returns a project option from its flag, or by asking the
+// user to choose between the available values. A free-form option accepts any
+// value, and its first available value is the default.
+func askProjectOption(cmd *cobra.Command, flagName, label string, available []string, freeForm bool) (
+	string, error) {
+	if value, _ := cmd.Flags().GetString(flagName); value != "" {
+		if !freeForm && len(available) > 0 && !slices.Contains(available, value) {
+			return "", fmt.Errorf("invalid --%s: %s (available: %s)", flagName, value, strings.Join(available, ", "))
+		}
+		return value, nil
+	}
+	switch {
+	case len(available) == 0:
+		return "", nil
+	case len(available) == 1 && !freeForm:
+		return available[0], nil
+	case !isInteractive():
+		if freeForm {
+			return available[0], nil
+		}
+		return "", fmt.Errorf("the --%s flag is required in non-interactive mode (available: %s)",
+			flagName, strings.Join(available, ", "))
+	case freeForm:
+		return askInput(cmd.ErrOrStderr(), label, available[0])
+	}
+	return choose(cmd.ErrOrStderr(), label, available)
+}
+
+// suggestedRegionFirst moves the suggested region to the front of the
+// available regions, so that it is the default choice.
+func suggestedRegionFirst(cmd *cobra.Command, client *api.Client, available []string) []string {
+	catalog, err := client.ListRegions(cmd.Context())
+	if err != nil {
+		debugLog("Could not list regions to suggest one: %s", err)
+		return available
+	}
+	r := suggestRegion(catalog, available, time.Now())
+	if r == nil {
+		return available
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Suggested region: %s\n", describeRegion(r))
+	sorted := []string{r.ID}
+	for _, id := range available {
+		if id != r.ID {
+			sorted = append(sorted, id)
+		}
+	}
+	return sorted
+}
+
+// askInput asks the user to enter a value.
+func askInput(stderr io.Writer, message, defaultValue string) (result string, err error) {
+	var renderer survey.Renderer
+	renderer.WithStdio(terminal.Stdio{Err: stderr})
+	prompt := &survey.Input{
+		Renderer: renderer,
+		Message:  message,
+		Default:  defaultValue,
+	}
+	err = survey.AskOne(prompt, &result, survey.WithValidator(survey.Required))
+	return
+}
+
+// checkCanCreate checks whether the user can create a project in the
+// organization. If an action is required first, such as verifying the user's
+// account, it is explained, and in interactive mode the check can be repeated
+// once the user has completed it.
+func checkCanCreate(cmd *cobra.Command, cnf *config.Config, client *api.Client, org *api.Organization) error {
+	stderr := cmd.ErrOrStderr()
+	for {
+		r, err := client.CanCreateSubscription(cmd.Context(), org.ID)
+		if err != nil {
+			return err
+		}
+		if r.CanCreate {
+			return nil
+		}
+		instructions, err := requiredActionInstructions(cmd, cnf, client, org, r)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stderr, color.YellowString(instructions))
+		if !isInteractive() || !confirmAction("Have you completed this? Check again?") {
+			return errors.New("a project cannot be created in the organization " + org.Name + " yet")
+		}
+	}
+}
+
+// requiredActionInstructions explains what the user must do before they can create a project.
+func requiredActionInstructions(
+	cmd *cobra.Command,
+	cnf *config.Config,
+	client *api.Client,
+	org *api.Organization,
+	r *api.CanCreateResult,
+) (string, error) {
+	msg := r.Message
+	if msg == "" {
+		msg = "You cannot create a project in the organization " + org.Name + "."
+	}
+	if r.RequiredAction == nil {
+		return msg, nil
+	}
+	consoleURL := strings.TrimRight(cnf.Service.ConsoleURL, "/")
+	switch r.RequiredAction.Action {
+	case "verification":
+		status, err := client.GetVerificationStatus(cmd.Context())
+		if err != nil {
+			return "", err
+		}
+		if status.Required && status.Type != "" {
+			msg += fmt.Sprintf("\nYou must verify your account (by %s) first.", status.Type)
+		} else {
+			msg += "\nYou must verify your account first."
+		}
+		if consoleURL != "" {
+			msg += "\nPlease open the following URL to continue: " + consoleURL
+		}
+	case "billing_details":
+		msg += "\nYou must add billing details to the organization first."
+		if consoleURL != "" {
+			msg += "\nPlease open the following URL to continue: " + consoleURL + "/" + org.Name + "/-/billing"
+		}
+	default:
+		msg += "\nRequired action: " + r.RequiredAction.Action
+	}
+	return msg, nil
+}
+
+// waitForSubscription waits, with a spinner, until a subscription's project is active.
+func waitForSubscription(cmd *cobra.Command, client *api.Client, sub *api.Subscription) (*api.Subscription, error) {
+	ctx := cmd.Context()
+	if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	stderr := cmd.ErrOrStderr()
+	fmt.Fprintln(stderr, "Waiting for the project to be provisioned...")
+	spinr := _init.NewSpinner(stderr)
+	spinr.Start()
+	defer spinr.Stop()
+
+	sub, err := client.WaitForSubscription(ctx, sub, subscriptionPollInterval)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, errors.New("timed out waiting for the project to be provisioned")
+	}
+	return sub, err
+}
+
+// setProjectRemote sets the new project as the Git remote of the repository
+// in the working directory, if there is one and the user wants to.
+func setProjectRemote(cmd *cobra.Command, cnf *config.Config, project *api.Project) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	gitRoot, err := findGitRoot(wd)
+	if err != nil || gitRoot == "" || project.Repository.URL == "" {
+		return err
+	}
+	setRemote, _ := cmd.Flags().GetBool("set-remote")
+	if !cmd.Flags().Changed("set-remote") && isInteractive() {
+		setRemote = confirmAction(fmt.Sprintf("Set the new project as the remote for the repository at %s?", gitRoot))
+	}
+	if !setRemote {
+		return nil
+	}
+	remoteName := cnf.Detection.GitRemoteName
+	if err := git.SetRemote(cmd.Context(), gitRoot, remoteName, project.Repository.URL); err != nil {
+		return fmt.Errorf("could not set the Git remote: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "The Git remote %s is set to: %s\n", remoteName, project.Repository.URL)
+	return nil
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+func TestProjectCreate(t *testing.T) {
+	interval := subscriptionPollInterval
+	subscriptionPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { subscriptionPollInterval = interval })
+
+	cnf, apiHandler := newTestAPI(t)
+	cnf.Service.ConsoleURL = "https://console.example.com"
+	testOrgs(apiHandler)
+	apiHandler.SetCanCreate("org-2", &mockapi.CanCreateResponse{
+		RequiredAction: &mockapi.CanCreateRequiredAction{Action: "billing_details", Type: "billing_details"},
+	})
+
+	_, err := runCommand(t, newProjectCreateCommand(cnf), "-o", "theirs", "--region", "test-region")
+	assert.EqualError(t, err, "a project cannot be created in the organization theirs yet")
+
+	_, err = runCommand(t, newProjectCreateCommand(cnf), "-o", "mine", "--region", "other-region")
+	assert.EqualError(t, err, "invalid --region: other-region (available: test-region)")
+
+	_, err = runCommand(t, newProjectCreateCommand(cnf), "-o", "mine", "--no-wait")
+	require.NoError(t, err)
+
+	initGitRepo(t, cnf.Detection.GitRemoteName, "abc123", "main")
+	out, err := runCommand(t, newProjectCreateCommand(cnf), "-o", "mine", "--title", "New site", "--set-remote")
+	require.NoError(t, err)
+	projectID := strings.TrimSpace(out)
+	require.NotEmpty(t, projectID)
+
+	remoteURL, err := exec.Command("git", "remote", "get-url", cnf.Detection.GitRemoteName).Output()
+	require.NoError(t, err)
+	assert.Equal(t, projectID+"@git.example.com:"+projectID+".git\n", string(remoteURL))
+}
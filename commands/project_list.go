@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+var projectListColumns = []tableColumn{
+	{Name: "id", Header: "ID"},
+	{Name: "title", Header: "Title"},
+	{Name: "region", Header: "Region"},
+	{Name: "organization_id", Header: "Organization ID"},
+	{Name: "organization_name", Header: "Organization"},
+	{Name: "organization_label", Header: "Organization label"},
+	{Name: "organization_type", Header: "Organization type"},
+	{Name: "status", Header: "Status"},
+	{Name: "created_at", Header: "Created"},
+}
+
+func newProjectListCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "project:list",
+		Aliases: []string{"projects", "pro"},
+		Short:   "Get a list of all active projects",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runProjectList(cmd, cnf)
+		},
+	}
+	cmd.Flags().Bool("pipe", false, "Output a simple list of project IDs. Disables pagination.")
+	cmd.Flags().String("region", "", "Filter by region (exact match)")
+	cmd.Flags().String("title", "", "Filter by title (case-insensitive search)")
+	cmd.Flags().Bool("my", false, "Display only the projects you own (through organizations you own)")
+	if cnf.API.EnableOrganizations {
+		cmd.Flags().StringP("org", "o", "", "Filter by organization name or ID")
+	}
+	cmd.Flags().String("sort", "title", "A property to sort by")
+	cmd.Flags().Bool("reverse", false, "Sort in reverse (descending) order")
+	addTableFlags(cmd, projectListColumns)
+	return cmd
+}
+
+func runProjectList(cmd *cobra.Command, cnf *config.Config) error {
+	client, err := newAPIClient(cmd, cnf)
+	if err != nil {
+		return err
+	}
+	me, err := client.GetMyUser(cmd.Context())
+	if err != nil {
+		return err
+	}
+	projects, err := client.ListUserProjects(cmd.Context(), me.ID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		flags       = cmd.Flags()
+		region, _   = flags.GetString("region")
+		title, _    = flags.GetString("title")
+		my, _       = flags.GetBool("my")
+		org, _      = flags.GetString("org")
+		filtersUsed []string
+	)
+	projects = slices.DeleteFunc(projects, func(p *api.UserProject) bool {
+		switch {
+		case region != "" && p.Region != region:
+			return true
+		case title != "" && !strings.Contains(strings.ToLower(p.Title), strings.ToLower(title)):
+			return true
+		case my && (p.Organization == nil || p.Organization.OwnerID != me.ID):
+			return true
+		case org != "" && (p.Organization == nil || (p.Organization.ID != org && p.Organization.Name != org)):
+			return true
+		}
+		return false
+	})
+	for _, f := range []string{"region", "title", "my", "org"} {
+		if flags.Changed(f) {
+			filtersUsed = append(filtersUsed, "--"+f)
+		}
+	}
+
+	t := &table{Columns: projectListColumns, DefaultColumns: []string{"id", "title", "region"}}
+	if cnf.API.EnableOrganizations {
+		t.DefaultColumns = append(t.DefaultColumns, "organization_name")
+	}
+	for _, p := range projects {
+		row := map[string]any{
+			"id":         p.ID,
+			"title":      p.Title,
+			"region":     p.Region,
+			"status":     p.Status,
+			"created_at": p.CreatedAt,
+		}
+		if p.Organization != nil {
+			row["organization_id"] = p.Organization.ID
+			row["organization_name"] = p.Organization.Name
+			row["organization_label"] = p.Organization.Label
+			row["organization_type"] = p.Organization.Type
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	sortField, _ := flags.GetString("sort")
+	reverse, _ := flags.GetBool("reverse")
+	sortRows(t.Rows, sortField, reverse)
+
+	if pipe, _ := flags.GetBool("pipe"); pipe {
+		for _, row := range t.Rows {
+			fmt.Fprintln(cmd.OutOrStdout(), row["id"])
+		}
+		return nil
+	}
+
+	stderr := cmd.ErrOrStderr()
+	if len(t.Rows) == 0 {
+		if len(filtersUsed) > 0 {
+			fmt.Fprintf(stderr, "No projects found (filters in use: %s).\n", strings.Join(filtersUsed, ", "))
+		} else {
+			fmt.Fprintf(stderr, "You do not have any %s projects yet.\n", cnf.Service.Name)
+		}
+		return nil
+	}
+
+	format, _ := flags.GetString("format")
+	if format == "table" {
+		fmt.Fprintln(stderr, "Your projects are: ")
+	}
+	if err := t.render(cmd); err != nil {
+		return err
+	}
+	if format == "table" {
+		fmt.Fprintln(stderr)
+		fmt.Fprintf(stderr, "Get a project by running: %s get [id]\n", cnf.Application.Executable)
+		fmt.Fprintf(stderr, "List a project's environments by running: %s environments -p [id]\n",
+			cnf.Application.Executable)
+	}
+	return nil
+}
+
+// sortRows sorts table rows by the formatted value of a column.
+func sortRows(rows []map[string]any, column string, reverse bool) {
+	if column == "" {
+		return
+	}
+	slices.SortStableFunc(rows, func(a, b map[string]any) int {
+		c := strings.Compare(strings.ToLower(formatValue(a[column])), strings.ToLower(formatValue(b[column])))
+		if reverse {
+			return -c
+		}
+		return c
+	})
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+func TestProjectList(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	apiHandler.SetMyUser(&mockapi.User{ID: "my-user-id"})
+	apiHandler.SetOrgs([]*mockapi.Org{
+		{ID: "org-1", Name: "mine", Label: "Mine", Type: "flexible", Owner: "my-user-id"},
+		{ID: "org-2", Name: "theirs", Label: "Theirs", Type: "fixed", Owner: "other-user-id"},
+	})
+	apiHandler.SetProjects([]*mockapi.Project{
+		{ID: "project-b", Title: "Beta", Region: "region-1", Organization: "org-1", CreatedAt: created},
+		{ID: "project-a", Title: "Alpha", Region: "region-2", Organization: "org-2", CreatedAt: created},
+	})
+	apiHandler.SetUserGrants([]*mockapi.UserGrant{
+		{ResourceID: "project-a", ResourceType: "project", OrganizationID: "org-2", UserID: "my-user-id"},
+		{ResourceID: "project-b", ResourceType: "project", OrganizationID: "org-1", UserID: "my-user-id"},
+	})
+
+	out, err := runCommand(t, newProjectListCommand(cnf))
+	require.NoError(t, err)
+	assert.Equal(t, `+-----------+-------+----------+--------------+
+| ID        | Title | Region   | Organization |
++-----------+-------+----------+--------------+
+| project-a | Alpha | region-2 | theirs       |
+| project-b | Beta  | region-1 | mine         |
++-----------+-------+----------+--------------+
+`, out)
+
+	out, err = runCommand(t, newProjectListCommand(cnf), "--format", "csv", "--columns", "id,created_at", "--reverse")
+	require.NoError(t, err)
+	assert.Equal(t, "ID,Created\nproject-b,2024-03-01T12:00:00+00:00\nproject-a,2024-03-01T12:00:00+00:00\n", out)
+
+	out, err = runCommand(t, newProjectListCommand(cnf), "--format", "plain", "--no-header", "-c", "id,org%", "--my")
+	require.NoError(t, err)
+	assert.Equal(t, "project-b\torg-1\tmine\tMine\tflexible\n", out)
+
+	out, err = runCommand(t, newProjectListCommand(cnf), "--pipe", "--org", "theirs")
+	require.NoError(t, err)
+	assert.Equal(t, "project-a\n", out)
+
+	out, err = runCommand(t, newProjectListCommand(cnf), "--format", "json", "--columns", "id,+region")
+	require.NoError(t, err)
+	var rows []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	assert.Equal(t, []map[string]any{
+		{"id": "project-a", "region": "region-2"},
+		{"id": "project-b", "region": "region-1"},
+	}, rows)
+
+	_, err = runCommand(t, newProjectListCommand(cnf), "--columns", "foo")
+	assert.ErrorContains(t, err, "column not found: foo")
+}
+
+func TestProjectInfo(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetProjects([]*mockapi.Project{
+		{ID: "project-a", Title: "Alpha", Region: "region-1", DefaultBranch: "main"},
+	})
+
+	out, err := runCommand(t, newProjectInfoCommand(cnf), "-p", "project-a", "title")
+	require.NoError(t, err)
+	assert.Equal(t, "Alpha\n", out)
+
+	out, err = runCommand(t, newProjectInfoCommand(cnf), "-p", "project-a", "--format", "json")
+	require.NoError(t, err)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &props))
+	assert.Equal(t, "main", props["default_branch"])
+	assert.NotContains(t, props, "_links")
+
+	out, err = runCommand(t, newProjectInfoCommand(cnf), "-p", "project-a", "--format", "csv")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Property,Value\n")
+	assert.Contains(t, out, "region,region-1\n")
+
+	_, err = runCommand(t, newProjectInfoCommand(cnf), "-p", "project-a", "missing")
+	assert.ErrorContains(t, err, "property not found: missing")
+}
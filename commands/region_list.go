@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+var regionColumns = []tableColumn{
+	{Name: "id", Header: "ID"},
+	{Name: "label", Header: "Label"},
+	{Name: "provider", Header: "Provider"},
+	{Name: "zone", Header: "Zone"},
+	{Name: "location", Header: "Location"},
+	{Name: "timezone", Header: "Timezone"},
+	{Name: "available", Header: "Available"},
+	{Name: "carbon_intensity", Header: "Carbon intensity (gCO2eq/kWh)"},
+	{Name: "green", Header: "Green"},
+}
+
+func newRegionListCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "region:list",
+		Aliases: []string{"regions"},
+		Short:   "List the regions where projects can be hosted",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRegionList(cmd, cnf)
+		},
+	}
+	if cnf.API.EnableOrganizations {
+		addOrgFlag(cmd)
+	}
+	cmd.Flags().String("provider", "", "Only list regions from this provider")
+	cmd.Flags().Bool("available", false, "Only list regions where a new project can be created")
+	addTableFlags(cmd, regionColumns)
+	return cmd
+}
+
+func runRegionList(cmd *cobra.Command, cnf *config.Config) error {
+	client, err := newAPIClient(cmd, cnf)
+	if err != nil {
+		return err
+	}
+	regions, err := client.ListRegions(cmd.Context())
+	if err != nil {
+		return err
+	}
+	org, orgRegions, err := organizationRegions(cmd, cnf, client)
+	if err != nil {
+		return err
+	}
+
+	provider, _ := cmd.Flags().GetString("provider")
+	onlyAvailable, _ := cmd.Flags().GetBool("available")
+	t := &table{
+		Columns:        regionColumns,
+		DefaultColumns: []string{"id", "provider", "zone", "location", "available", "carbon_intensity"},
+	}
+	for _, r := range regions {
+		available := regionAvailable(r, orgRegions)
+		if (provider != "" && !strings.EqualFold(r.Provider.Name, provider)) || (onlyAvailable && !available) {
+			continue
+		}
+		row := map[string]any{
+			"id":        r.ID,
+			"label":     cmp.Or(r.SelectionLabel, r.Label),
+			"provider":  r.Provider.Name,
+			"zone":      r.Zone,
+			"location":  cmp.Or(r.Datacenter.Location, r.Datacenter.Label),
+			"timezone":  r.Timezone,
+			"available": available,
+		}
+		if impact := r.EnvironmentalImpact; impact != nil {
+			row["carbon_intensity"] = impact.CarbonIntensity
+			row["green"] = impact.Green
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	sortRows(t.Rows, "id", false)
+	sortRows(t.Rows, "zone", false)
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "table" {
+		if org != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Availability is shown for the organization %s.\n", org.Name)
+		}
+		if len(t.Rows) == 0 {
+			fmt.Fprintln(cmd.ErrOrStderr(), "No regions were found.")
+			return nil
+		}
+	}
+	return t.render(cmd)
+}
+
+// organizationRegions returns the IDs of the regions available to the
+// selected organization. If no organization can be determined (and none was
+// specified), the regions are nil, meaning availability is not restricted.
+func organizationRegions(cmd *cobra.Command, cnf *config.Config, client *api.Client) (
+	*api.Organization, []string, error) {
+	if !cnf.API.EnableOrganizations {
+		return nil, nil, nil
+	}
+	org, err := selectOrganization(cmd, cnf, client)
+	if err != nil {
+		if cmd.Flags().Changed("org") {
+			return nil, nil, err
+		}
+		debugLog("Showing availability without an organization: %s", err)
+		return nil, nil, nil
+	}
+	setupOptions, err := client.GetSetupOptions(cmd.Context(), org.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return org, setupOptions.Regions, nil
+}
+
+// regionAvailable returns whether a new project can be created in a region.
+// If orgRegions is not nil, the region must also be one of them.
+func regionAvailable(r *api.Region, orgRegions []string) bool {
+	return r.Available && (orgRegions == nil || slices.Contains(orgRegions, r.ID))
+}
+
+// suggestRegion suggests a region for a new project from those which are
+// available: the region whose timezone is closest to the local time's, with
+// the lowest carbon intensity breaking ties. Regions with an unknown timezone
+// are considered the furthest away. It returns nil if no region is available.
+func suggestRegion(regions []*api.Region, orgRegions []string, now time.Time) *api.Region {
+	_, localOffset := now.Zone()
+	distance := func(r *api.Region) int {
+		loc, err := time.LoadLocation(r.Timezone)
+		if r.Timezone == "" || err != nil {
+			return 24 * 60 * 60
+		}
+		_, offset := now.In(loc).Zone()
+		d := offset - localOffset
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+	carbonIntensity := func(r *api.Region) int {
+		if r.EnvironmentalImpact == nil {
+			return 1 << 30
+		}
+		return r.EnvironmentalImpact.CarbonIntensity
+	}
+	var candidates []*api.Region
+	for _, r := range regions {
+		if regionAvailable(r, orgRegions) {
+			candidates = append(candidates, r)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	return slices.MinFunc(candidates, func(a, b *api.Region) int {
+		return cmp.Or(
+			cmp.Compare(distance(a), distance(b)),
+			cmp.Compare(carbonIntensity(a), carbonIntensity(b)),
+			strings.Compare(a.ID, b.ID),
+		)
+	})
+}
+
+// describeRegion returns a short description of a region, with its carbon intensity if known.
+func describeRegion(r *api.Region) string {
+	desc := r.ID
+	if details := cmp.Or(r.Datacenter.Location, r.Zone); details != "" {
+		desc += " (" + details + ")"
+	}
+	if r.EnvironmentalImpact != nil {
+		desc += fmt.Sprintf(", %d gCO2eq/kWh", r.EnvironmentalImpact.CarbonIntensity)
+	}
+	return desc
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/internal/api"
+)
+
+func TestRegionList(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetRegions(testRegions())
+
+	// Without an organization, availability is as reported by the region.
+	out, err := runCommand(t, newRegionListCommand(cnf), "--format", "csv", "--columns", "id,provider,available")
+	require.NoError(t, err)
+	assert.Equal(t, `ID,Provider,Available
+de-region,AWS,true
+old-region,AWS,false
+test-region,OVHcloud,true
+us-region,AWS,true
+`, out)
+
+	out, err = runCommand(t, newRegionListCommand(cnf), "--provider", "aws", "--available",
+		"--format", "csv", "--columns", "id")
+	require.NoError(t, err)
+	assert.Equal(t, "ID\nde-region\nus-region\n", out)
+
+	// The organization's setup options restrict availability.
+	testOrgs(apiHandler)
+	out, err = runCommand(t, newRegionListCommand(cnf), "-o", "mine", "--available", "--format", "json")
+	require.NoError(t, err)
+	var rows []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, map[string]any{
+		"id":               "test-region",
+		"provider":         "OVHcloud",
+		"zone":             "Europe",
+		"location":         "France",
+		"available":        true,
+		"carbon_intensity": float64(56),
+	}, rows[0])
+}
+
+func TestSuggestRegion(t *testing.T) {
+	var regions []*api.Region
+	b, err := json.Marshal(testRegions())
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &regions))
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// The closest timezone wins, with carbon intensity breaking ties.
+	assert.Equal(t, "test-region", suggestRegion(regions, nil, now.In(paris)).ID)
+	assert.Equal(t, "us-region", suggestRegion(regions, nil, now.In(newYork)).ID)
+	assert.Equal(t, "de-region", suggestRegion(regions, []string{"de-region", "us-region"}, now.In(paris)).ID)
+	assert.Nil(t, suggestRegion(regions, []string{"old-region"}, now))
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -19,13 +20,17 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/platformsh/cli/internal"
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/auth"
 	"github.com/platformsh/cli/internal/config"
 	"github.com/platformsh/cli/internal/config/alt"
+	"github.com/platformsh/cli/internal/httpclient"
 	"github.com/platformsh/cli/internal/legacy"
 )
 
-// Execute is the main entrypoint to run the CLI.
-func Execute(cnf *config.Config) error {
+// Execute is the main entrypoint to run the CLI. On failure, it prints the
+// error and exits with an exit code for the class of error.
+func Execute(cnf *config.Config) {
 	assets := &vendorization.VendorAssets{
 		Use:          "project:init",
 		Binary:       cnf.Application.Executable,
@@ -36,7 +41,9 @@ func Execute(cnf *config.Config) error {
 	}
 
 	ctx := vendorization.WithVendorAssets(config.ToContext(context.Background(), cnf), assets)
-	return newRootCommand(cnf, assets).ExecuteContext(ctx)
+	if err := newRootCommand(cnf, assets).ExecuteContext(ctx); err != nil {
+		exitWithError(cnf, err)
+	}
 }
 
 func newRootCommand(cnf *config.Config, assets *vendorization.VendorAssets) *cobra.Command {
@@ -51,7 +58,7 @@ func newRootCommand(cnf *config.Config, assets *vendorization.VendorAssets) *cob
 		DisableFlagParsing: false,
 		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
 		SilenceUsage:       true,
-		SilenceErrors:      false,
+		SilenceErrors:      true,
 		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
 			if viper.GetBool("quiet") && !viper.GetBool("debug") && !viper.GetBool("verbose") {
 				viper.Set("no-interaction", true)
@@ -68,6 +75,9 @@ func newRootCommand(cnf *config.Config, assets *vendorization.VendorAssets) *cob
 			if viper.GetBool("yes") {
 				viper.Set("no-interaction", true)
 			}
+			if err := auth.ApplySessionID(cnf, viper.GetString("session")); err != nil {
+				exitWithError(cnf, err)
+			}
 			if viper.GetBool("version") {
 				versionCommand.Run(cmd, []string{})
 				os.Exit(0)
@@ -88,8 +98,8 @@ func newRootCommand(cnf *config.Config, assets *vendorization.VendorAssets) *cob
 		},
 		Run: func(cmd *cobra.Command, _ []string) {
 			c := makeLegacyCLIWrapper(cnf, cmd.OutOrStdout(), cmd.ErrOrStderr(), cmd.InOrStdin())
-			if err := c.Exec(cmd.Context(), os.Args[1:]...); err != nil {
-				exitWithError(err)
+			if err := c.Exec(cmd.Context(), removeWrapperFlags(os.Args[1:])...); err != nil {
+				exitWithError(cnf, err)
 			}
 		},
 		PersistentPostRun: func(cmd *cobra.Command, _ []string) {
@@ -118,8 +128,8 @@ func newRootCommand(cnf *config.Config, assets *vendorization.VendorAssets) *cob
 		}
 
 		c := makeLegacyCLIWrapper(cnf, cmd.OutOrStdout(), cmd.ErrOrStderr(), cmd.InOrStdin())
-		if err := c.Exec(cmd.Context(), args...); err != nil {
-			exitWithError(err)
+		if err := c.Exec(cmd.Context(), removeWrapperFlags(args)...); err != nil {
+			exitWithError(cnf, err)
 		}
 	})
 
@@ -128,10 +138,19 @@ func newRootCommand(cnf *config.Config, assets *vendorization.VendorAssets) *cob
 	cmd.PersistentFlags().Bool("no-interaction", false, "Enable non-interactive mode")
 	cmd.PersistentFlags().BoolP("yes", "y", false, "Answer yes to all confirmation questions; implies --no-interaction")
 	cmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
+	// The API cache flags are named so that they cannot collide with legacy
+	// command options, such as the --refresh option of project:list.
+	cmd.PersistentFlags().Bool("no-api-cache", false, "Disable the cache of API responses")
+	cmd.PersistentFlags().Bool("refresh-api-cache", false, "Ignore cached API responses, and fetch fresh data")
+	cmd.PersistentFlags().String("session", "",
+		"Use a named authentication session for this command, instead of the active one")
 	cmd.PersistentFlags().BoolP("quiet", "q", false,
 		"Suppress any messages and errors (stderr), while continuing to display necessary output (stdout)."+
 			" This implies --no-interaction. Ignored in verbose mode.",
 	)
+	cmd.PersistentFlags().Bool("compare-legacy", false,
+		"Compare the output of a native command with the legacy CLI, and report the differences")
+	_ = cmd.PersistentFlags().MarkHidden("compare-legacy")
 
 	validateCmd := commands.NewValidateCommand(assets)
 	validateCmd.Use = "app:config-validate"
@@ -143,14 +162,21 @@ func newRootCommand(cnf *config.Config, assets *vendorization.VendorAssets) *cob
 
 	// Add subcommands.
 	cmd.AddCommand(
+		newAuthBrowserLoginCommand(cnf),
+		newAuthSessionListCommand(cnf),
+		newAuthSessionCreateCommand(cnf),
+		newAuthSessionSwitchCommand(cnf),
+		newAuthSessionDeleteCommand(cnf),
 		newConfigInstallCommand(),
 		newCompletionCommand(cnf),
+		newCompletionHookCommand(cnf),
 		newHelpCommand(cnf),
 		newInitCommand(cnf, assets),
 		newListCommand(cnf),
 		validateCmd,
 		versionCommand,
 	)
+	cmd.AddCommand(nativeCommands(cnf)...)
 	if cnf.Service.ProjectConfigFlavor == "upsun" {
 		cmd.AddCommand(newProjectConvertCommand(cnf))
 	}
@@ -252,7 +278,7 @@ func debugLog(format string, v ...any) {
 	fmt.Fprintf(color.Error, prefix+" "+strings.TrimSpace(format)+"\n", v...)
 }
 
-func exitWithError(err error) {
+func exitWithError(cnf *config.Config, err error) {
 	var execErr *exec.ExitError
 	if errors.As(err, &execErr) {
 		exitCode := execErr.ExitCode()
@@ -260,9 +286,81 @@ func exitWithError(err error) {
 		os.Exit(exitCode)
 	}
 	if !viper.GetBool("quiet") {
-		fmt.Fprintln(color.Error, color.RedString(err.Error()))
+		renderError(color.Error, cnf, err, viper.GetBool("debug"))
+	}
+	os.Exit(exitCodeForError(err))
+}
+
+// removeWrapperFlags removes flags which only apply to this CLI from
+// arguments destined for the legacy CLI. The session is passed to the legacy
+// CLI through the environment instead. Values given to boolean flags (e.g.
+// --no-api-cache=1) are left alone, as they may be options of the legacy command.
+func removeWrapperFlags(args []string) []string {
+	result := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--":
+			return append(result, args[i:]...)
+		case args[i] == "--session":
+			i++
+		case strings.HasPrefix(args[i], "--session="), args[i] == "--no-api-cache",
+			args[i] == "--refresh-api-cache", args[i] == "--compare-legacy":
+		default:
+			result = append(result, args[i])
+		}
+	}
+	return result
+}
+
+// withAPITransport returns a context carrying the API transport chain, for
+// clients created by auth.NewLegacyCLIClient. Responses are cached per auth
+// session and user (unless disabled with --no-api-cache), and failed requests are
+// retried with rate limiting.
+//
+// The --no-api-cache and --refresh-api-cache flags are read from the command
+// rather than through viper, so that they cannot be enabled by environment
+// variables.
+func withAPITransport(cmd *cobra.Command, cnf *config.Config) context.Context {
+	ctx := cmd.Context()
+	base, ok := auth.TransportFromContext(ctx)
+	if !ok {
+		base = httpclient.NewTransport(cnf)
+	}
+	retry := api.NewTransport(base, nil)
+	retry.LogFunc = debugLog
+
+	cache := &api.CacheTransport{Base: retry, LogFunc: debugLog}
+	noCache, _ := cmd.Flags().GetBool("no-api-cache")
+	refresh, _ := cmd.Flags().GetBool("refresh-api-cache")
+	switch {
+	case noCache:
+		cache.Mode = api.CacheDisabled
+	case refresh:
+		cache.Mode = api.CacheRefresh
+	}
+	if cache.Mode != api.CacheDisabled {
+		dir, err := apiCacheDir(cnf)
+		if err != nil {
+			debugLog("Cannot use the API cache: %s", err)
+		}
+		cache.Dir = dir
+	}
+	return auth.WithTransport(ctx, cache)
+}
+
+// apiCacheDir returns the directory for cached API responses, for the current
+// auth session and user. The user is read from the session on each run, as the
+// legacy CLI may log in to the same session as another user.
+func apiCacheDir(cnf *config.Config) (string, error) {
+	dir, err := api.CacheDir(cnf, cnf.API.SessionID)
+	if err != nil {
+		return "", err
+	}
+	key, err := auth.IdentityKey(cnf)
+	if err != nil {
+		return "", err
 	}
-	os.Exit(1)
+	return filepath.Join(dir, cmp.Or(key, "anonymous")), nil
 }
 
 func makeLegacyCLIWrapper(cnf *config.Config, stdout, stderr io.Writer, stdin io.Reader) *legacy.CLIWrapper {
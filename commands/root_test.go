@@ -0,0 +1,16 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveWrapperFlags(t *testing.T) {
+	assert.Equal(t, []string{"project:list", "--format", "csv", "--", "--no-api-cache"},
+		removeWrapperFlags([]string{
+			"project:list", "--session", "work", "--refresh-api-cache", "--format", "csv", "--no-api-cache",
+			"--compare-legacy", "--", "--no-api-cache",
+		}))
+	assert.Equal(t, []string{"help"}, removeWrapperFlags([]string{"--session=work", "help"}))
+}
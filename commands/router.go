@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/platformsh/cli/internal/config"
+)
+
+// nativeCommand is a Go-native command, registered in place of legacy CLI commands.
+type nativeCommand struct {
+	*cobra.Command
+
+	// Replaces lists the legacy CLI command which the native command
+	// replaces, followed by the legacy command's aliases. It is empty for
+	// commands which only exist natively.
+	Replaces []string
+
+	// ReadOnly reports whether the command, run with the given arguments,
+	// only reads data. Only read-only commands can be compared with the
+	// legacy CLI (see withLegacyComparison). It is nil for commands which
+	// make changes, and for commands which only exist natively.
+	ReadOnly func(args []string) bool
+
+	// NativeFlags lists the command's flags which the legacy command does
+	// not have. They are not passed to the legacy CLI when comparing outputs.
+	NativeFlags []string
+}
+
+// readOnly is the ReadOnly function for commands which never make changes.
+func readOnly([]string) bool {
+	return true
+}
+
+// readOnlyWithoutValue is the ReadOnly function for "info" commands, which
+// make changes when given a property value to set.
+func readOnlyWithoutValue(args []string) bool {
+	return len(args) < 2
+}
+
+// names returns every name the command replaces: its own name and aliases,
+// and the other legacy names it replaces.
+func (n nativeCommand) names() []string {
+	names := append([]string{n.Name()}, n.Aliases...)
+	for _, name := range n.Replaces {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// legacyName returns the name of the legacy CLI command which the native
+// command replaces, or its own name if it only exists natively.
+func (n nativeCommand) legacyName() string {
+	if len(n.Replaces) > 0 {
+		return n.Replaces[0]
+	}
+	return n.Name()
+}
+
+// commandRouter decides which commands run natively, and which are passed
+// through to the legacy CLI. Native commands can be switched back to the
+// legacy CLI in the config (wrapper.legacy_commands), or with the
+// {ENV_PREFIX}LEGACY_COMMANDS environment variable.
+type commandRouter struct {
+	cnf    *config.Config
+	native []nativeCommand
+}
+
+// newCommandRouter creates a router for native commands, from a registry
+// function (usually nativeCommandRegistry). The router owns the commands
+// which the registry returns: it sets their aliases to every legacy name they
+// replace, and wraps their RunE. So the registry is called for each router,
+// and must create new commands on each call.
+func newCommandRouter(cnf *config.Config, registry func(*config.Config) []nativeCommand) *commandRouter {
+	r := &commandRouter{cnf: cnf}
+	patterns := legacyCommandPatterns(cnf)
+	for _, n := range registry(cnf) {
+		if pattern, ok := matchCommandPattern(n.names(), patterns); ok {
+			debugLog("Using the legacy CLI for the command %s (matching %q)", n.Name(), pattern)
+			continue
+		}
+		// Invoking any legacy name runs the native command.
+		n.Aliases = n.names()[1:]
+		withLegacyComparison(n, legacyComparisonRunner(cnf))
+		r.native = append(r.native, n)
+	}
+	return r
+}
+
+// legacyCommandPatterns returns the names or patterns of the native commands
+// which should be run by the legacy CLI instead.
+func legacyCommandPatterns(cnf *config.Config) []string {
+	patterns := slices.Clone(cnf.Wrapper.LegacyCommands)
+	for _, p := range strings.Split(os.Getenv(cnf.Application.EnvPrefix+"LEGACY_COMMANDS"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// matchCommandPattern returns the first pattern matching any of the command names.
+func matchCommandPattern(names, patterns []string) (string, bool) {
+	for _, p := range patterns {
+		for _, name := range names {
+			if ok, _ := path.Match(p, name); ok {
+				return p, true
+			}
+		}
+	}
+	return "", false
+}
+
+// commands returns the enabled native commands.
+func (r *commandRouter) commands() []*cobra.Command {
+	cmds := make([]*cobra.Command, len(r.native))
+	for i, n := range r.native {
+		cmds[i] = n.Command
+	}
+	return cmds
+}
+
+// find returns the enabled native command with the given name or alias.
+func (r *commandRouter) find(name string) *cobra.Command {
+	for _, n := range r.native {
+		if slices.Contains(n.names(), name) {
+			return n.Command
+		}
+	}
+	return nil
+}
+
+// mergeInto merges the enabled native commands into a legacy CLI command
+// list, replacing the legacy commands (and aliases) which they replace.
+func (r *commandRouter) mergeInto(list *List) {
+	for _, n := range r.native {
+		names := n.names()
+		list.RemoveCommands(names)
+		internalCmd := innerNativeCommand(r.cnf, n.Command)
+		if !list.DescribesNamespace() || list.Namespace == internalCmd.Name.Namespace {
+			list.AddCommand(&internalCmd)
+		}
+	}
+}
+
+// completeNative returns completion suggestions from the native commands,
+// for the command line up to the cursor: command names (and aliases) when
+// completing the first argument, or a native command's flags.
+func (r *commandRouter) completeNative(line string) []string {
+	words := strings.Fields(line)
+	current := ""
+	if len(words) > 0 && !strings.HasSuffix(line, " ") {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	var suggestions []string
+	switch {
+	case len(words) == 1:
+		for _, n := range r.native {
+			if n.Hidden {
+				continue
+			}
+			for _, name := range n.names() {
+				if strings.HasPrefix(name, current) {
+					suggestions = append(suggestions, name)
+				}
+			}
+		}
+	case len(words) > 1 && strings.HasPrefix(current, "-"):
+		cmd := r.find(words[1])
+		if cmd == nil {
+			return nil
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			if !f.Hidden && strings.HasPrefix("--"+f.Name, current) {
+				suggestions = append(suggestions, "--"+f.Name)
+			}
+		})
+	}
+	return suggestions
+}
@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/internal/config"
+)
+
+func testRouterRegistry(*config.Config) []nativeCommand {
+	newCmd := func(use string, aliases ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: use, Aliases: aliases, Short: "Native " + use, Run: func(*cobra.Command, []string) {}}
+		cmd.Flags().String("format", "table", "")
+		return cmd
+	}
+	return []nativeCommand{
+		{Command: newCmd("project:list", "projects"), Replaces: []string{"project:list", "projects", "pro"}},
+		{Command: newCmd("organization:user:remove"), Replaces: []string{"organization:user:delete", "org:user:del"}},
+		{Command: newCmd("variable:list", "variables"), Replaces: []string{"variable:list", "variables"}},
+		{Command: newCmd("variable:get", "vget"), Replaces: []string{"variable:get", "vget"}},
+	}
+}
+
+func TestCommandRouterKillSwitch(t *testing.T) {
+	cnf := &config.Config{}
+	cnf.Application.EnvPrefix = "TEST_CLI_"
+	cnf.Wrapper.LegacyCommands = []string{"projects"}
+	t.Setenv("TEST_CLI_LEGACY_COMMANDS", "variable:*, other")
+
+	r := newCommandRouter(cnf, testRouterRegistry)
+	var names []string
+	for _, cmd := range r.commands() {
+		names = append(names, cmd.Name())
+	}
+	assert.Equal(t, []string{"organization:user:remove"}, names)
+
+	// Replaced legacy names are routed to the native command.
+	assert.Equal(t, []string{"organization:user:delete", "org:user:del"}, r.commands()[0].Aliases)
+	assert.NotNil(t, r.find("organization:user:delete"))
+	assert.Nil(t, r.find("projects"))
+
+	// Each router has its own commands.
+	other := newCommandRouter(&config.Config{}, testRouterRegistry)
+	assert.NotSame(t, r.find("organization:user:remove"), other.find("organization:user:remove"))
+	assert.Equal(t, []string{"organization:user:delete", "org:user:del"}, other.find("org:user:del").Aliases)
+}
+
+func TestCommandRouterLegacyOptions(t *testing.T) {
+	cnf := &config.Config{}
+	cnf.Application.EnvPrefix = "TEST_CLI_"
+	t.Setenv("TEST_CLI_LEGACY_COMMANDS", "project:list")
+	require.Nil(t, newCommandRouter(cnf, testRouterRegistry).find("project:list"))
+
+	// The legacy command's own --refresh option is passed through, with its value.
+	assert.Equal(t, []string{"project:list", "--refresh", "0"},
+		removeWrapperFlags([]string{"project:list", "--refresh", "0", "--no-api-cache"}))
+	assert.Equal(t, []string{"project:list", "--refresh=0"},
+		removeWrapperFlags([]string{"project:list", "--refresh-api-cache", "--refresh=0"}))
+}
+
+func TestCommandRouterMergeList(t *testing.T) {
+	cnf := &config.Config{}
+	cnf.Application.Executable = "test-cli"
+	list := &List{
+		Commands: []*Command{
+			{Name: CommandName{Namespace: "organization", Command: "user:list"},
+				Aliases: []string{"organization:users", "org:user:del"}},
+			{Name: CommandName{Namespace: "project", Command: "list"}, Aliases: []string{"projects", "pro"}},
+			{Name: CommandName{Namespace: "organization", Command: "user:delete"}},
+		},
+		Namespaces: []Namespace{
+			{ID: "organization", Commands: []string{"organization:user:delete", "organization:user:list"}},
+			{ID: "project", Commands: []string{"project:list"}},
+		},
+	}
+	newCommandRouter(cnf, testRouterRegistry).mergeInto(list)
+
+	var names []string
+	for _, c := range list.Commands {
+		names = append(names, c.Name.String())
+	}
+	assert.Equal(t, []string{
+		"organization:user:list",
+		"organization:user:remove",
+		"project:list",
+		"variable:get",
+		"variable:list",
+	}, names)
+	assert.Equal(t, []string{"organization:users"}, list.Commands[0].Aliases)
+	assert.Equal(t, "Native project:list", list.Commands[2].Description.String())
+	assert.Equal(t, []Namespace{
+		{ID: "organization", Commands: []string{"organization:user:list", "organization:user:remove"}},
+		{ID: "project", Commands: []string{"project:list"}},
+	}, list.Namespaces)
+}
+
+func TestCommandRouterCompletion(t *testing.T) {
+	r := newCommandRouter(&config.Config{}, testRouterRegistry)
+
+	assert.Equal(t, []string{"variable:list", "variables", "variable:get"}, r.completeNative("test-cli vari"))
+	assert.Equal(t, []string{"--format"}, r.completeNative("test-cli vget --f"))
+	assert.Empty(t, r.completeNative("test-cli unknown --f"))
+	assert.Empty(t, r.completeNative("test-cli variables name"))
+
+	assert.Equal(t, []string{"variable:list", "variable:delete", "variables"},
+		mergeCompletions([]string{"variable:list", "variable:delete", ""}, []string{"variable:list", "variables"}))
+}
+
+// TestNativeCommandRegistryReplaces checks the legacy commands which the
+// native commands replace, against a legacy CLI "list --format=json" output.
+func TestNativeCommandRegistryReplaces(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "legacy_list.json"))
+	require.NoError(t, err)
+	var list List
+	require.NoError(t, json.Unmarshal(b, &list))
+	legacyCommands := map[string]*Command{}
+	for _, c := range list.Commands {
+		for _, name := range append([]string{c.Name.String()}, c.Aliases...) {
+			legacyCommands[name] = c
+		}
+	}
+
+	cnf := &config.Config{}
+	cnf.API.EnableOrganizations = true
+	for _, n := range nativeCommandRegistry(cnf) {
+		t.Run(n.Name(), func(t *testing.T) {
+			if len(n.Replaces) > 0 {
+				legacyCmd, ok := legacyCommands[n.Replaces[0]]
+				require.True(t, ok, "legacy command not found: %s", n.Replaces[0])
+				assert.Equal(t, n.Replaces[0], legacyCmd.Name.String())
+				assert.Equal(t, legacyCmd.Aliases, n.Replaces[1:], "the legacy command's aliases are replaced")
+			}
+			// No name may be taken from another legacy command.
+			for _, name := range n.names() {
+				if legacyCmd, ok := legacyCommands[name]; ok {
+					assert.Equal(t, n.legacyName(), legacyCmd.Name.String(),
+						"the name %s belongs to the legacy command %s", name, legacyCmd.Name.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/selection"
+)
+
+// addSelectionFlags adds the --project, and optionally --environment, flags to a command.
+func addSelectionFlags(cmd *cobra.Command, withEnvironment bool) {
+	cmd.Flags().StringP("project", "p", "", "The project ID or URL")
+	if withEnvironment {
+		cmd.Flags().StringP("environment", "e", "", "The environment ID")
+	}
+}
+
+// newSelectionResolver returns a resolver for the current project and environment.
+func newSelectionResolver(cnf *config.Config) *selection.Resolver {
+	return &selection.Resolver{Config: cnf, Debug: debugLog}
+}
+
+// selectionInput reads the selection input from a command's flags (if it has
+// them) and the working directory.
+func selectionInput(cmd *cobra.Command) selection.Input {
+	in := selection.Input{}
+	in.Project, _ = cmd.Flags().GetString("project")
+	in.Environment, _ = cmd.Flags().GetString("environment")
+	in.Dir, _ = os.Getwd()
+	return in
+}
+
+// selectProject returns the current project ID, from the --project flag, the
+// environment, or the project's local configuration or Git repository.
+func selectProject(cmd *cobra.Command, cnf *config.Config) (string, error) {
+	if projectID, _ := newSelectionResolver(cnf).Project(cmd.Context(), selectionInput(cmd)); projectID != "" {
+		return projectID, nil
+	}
+	return "", errors.New("could not determine the current project: " +
+		"specify it using --project, or go to a project directory")
+}
+
+// selectEnvironment returns the current environment, from the --environment
+// flag, the environment, or the one matching the current Git branch.
+func selectEnvironment(
+	cmd *cobra.Command,
+	cnf *config.Config,
+	client *api.Client,
+	projectID string,
+) (*api.Environment, error) {
+	envID, _ := newSelectionResolver(cnf).Environment(cmd.Context(), selectionInput(cmd))
+	if envID == "" {
+		return nil, errors.New("could not determine the current environment: " +
+			"specify it using --environment")
+	}
+	return findEnvironment(cmd, client, projectID, envID)
+}
+
+// findEnvironment finds an environment by its ID, name or machine name.
+func findEnvironment(cmd *cobra.Command, client *api.Client, projectID, id string) (*api.Environment, error) {
+	envs, err := client.ListEnvironments(cmd.Context(), projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, env := range envs {
+		if env.ID == id {
+			return env, nil
+		}
+	}
+	for _, env := range envs {
+		if env.Name == id || env.MachineName == id {
+			return env, nil
+		}
+	}
+	return nil, fmt.Errorf("environment not found: %s (project: %s)", id, projectID)
+}
+
+// addOrgFlag adds the --org flag to a command.
+func addOrgFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("org", "o", "", "The organization name or ID")
+}
+
+// selectOrganization returns the organization from the --org flag, or the
+// current project's organization, or the user's only organization.
+func selectOrganization(cmd *cobra.Command, cnf *config.Config, client *api.Client) (*api.Organization, error) {
+	if nameOrID, _ := cmd.Flags().GetString("org"); nameOrID != "" {
+		return findOrganization(cmd, client, nameOrID)
+	}
+	if projectID, _ := newSelectionResolver(cnf).Project(cmd.Context(), selectionInput(cmd)); projectID != "" {
+		project, err := client.GetProject(cmd.Context(), projectID)
+		if err != nil {
+			return nil, err
+		}
+		if project.Organization != "" {
+			debugLog("Using the organization of the project %s: %s", projectID, project.Organization)
+			return client.GetOrganization(cmd.Context(), project.Organization)
+		}
+	}
+	me, err := client.GetMyUser(cmd.Context())
+	if err != nil {
+		return nil, err
+	}
+	orgs, err := client.ListUserOrganizations(cmd.Context(), me.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(orgs) == 1 {
+		return orgs[0], nil
+	}
+	return nil, errors.New("could not determine the organization: specify it using --org")
+}
+
+// findOrganization finds an organization by its ID or name.
+func findOrganization(cmd *cobra.Command, client *api.Client, nameOrID string) (*api.Organization, error) {
+	org, err := client.GetOrganization(cmd.Context(), nameOrID)
+	if err == nil || !api.IsNotFound(err) {
+		return org, err
+	}
+	org, err = client.GetOrganizationByName(cmd.Context(), nameOrID)
+	if api.IsNotFound(err) {
+		return nil, fmt.Errorf("organization not found: %s", nameOrID)
+	}
+	return org, err
+}
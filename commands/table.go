@@ -0,0 +1,343 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// tableFormats are the output formats supported by tables, as in the legacy CLI (plus JSON).
+var tableFormats = []string{"table", "csv", "tsv", "plain", "json"}
+
+// dateFormat is the date format used in tables (equivalent to PHP's "c" format).
+const dateFormat = "2006-01-02T15:04:05-07:00"
+
+// tableColumn is a column which can be selected for output.
+type tableColumn struct {
+	Name   string // The machine name, used in --columns and as the JSON key.
+	Header string // The header displayed in the table.
+}
+
+// table is tabular data, which can be output in several formats.
+type table struct {
+	Columns        []tableColumn
+	DefaultColumns []string
+	Rows           []map[string]any // Rows of values keyed by column name.
+}
+
+// addTableFlags adds the flags for table output formatting to a command.
+func addTableFlags(cmd *cobra.Command, columns []tableColumn) {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	cmd.Flags().String("format", "table", "The output format: "+strings.Join(tableFormats, ", "))
+	cmd.Flags().StringSliceP("columns", "c", nil,
+		"Columns to display. Available columns: "+strings.Join(names, ", ")+
+			" (the % or * characters may be used as a wildcard, and a + prefix adds to the default columns)")
+	cmd.Flags().Bool("no-header", false, "Do not output the table header")
+}
+
+// addPropertyFlags adds the flags for property list output formatting to a command.
+func addPropertyFlags(cmd *cobra.Command) {
+	cmd.Flags().String("format", "table", "The output format: "+strings.Join(tableFormats, ", "))
+}
+
+// render outputs the table according to the command's formatting flags.
+func (t *table) render(cmd *cobra.Command) error {
+	format, _ := cmd.Flags().GetString("format")
+	columnSpec, _ := cmd.Flags().GetStringSlice("columns")
+	noHeader, _ := cmd.Flags().GetBool("no-header")
+
+	columns, err := selectColumns(columnSpec, t.Columns, t.DefaultColumns)
+	if err != nil {
+		return err
+	}
+	return writeTable(cmd.OutOrStdout(), format, columns, t.Rows, !noHeader)
+}
+
+// selectColumns resolves the --columns option, which may contain wildcards
+// or a "+" prefix (to add to the default columns).
+func selectColumns(spec []string, available []tableColumn, defaults []string) ([]tableColumn, error) {
+	byName := make(map[string]tableColumn, len(available))
+	for _, c := range available {
+		byName[c.Name] = c
+	}
+	if len(spec) == 0 {
+		spec = defaults
+	} else if strings.HasPrefix(spec[0], "+") {
+		spec = append(slices.Clone(defaults), spec...)
+	}
+
+	var selected []tableColumn
+	add := func(c tableColumn) {
+		if !slices.Contains(selected, c) {
+			selected = append(selected, c)
+		}
+	}
+	for _, s := range spec {
+		s = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(s, "+")))
+		if s == "" {
+			continue
+		}
+		if strings.ContainsAny(s, "*%") {
+			pattern := strings.ReplaceAll(s, "%", "*")
+			var found bool
+			for _, c := range available {
+				if ok, _ := path.Match(pattern, c.Name); ok {
+					add(c)
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("no columns match: %s", s)
+			}
+			continue
+		}
+		if c, ok := byName[s]; ok {
+			add(c)
+			continue
+		}
+		var found bool
+		for _, c := range available {
+			if strings.EqualFold(c.Header, s) {
+				add(c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			names := make([]string, len(available))
+			for i, c := range available {
+				names[i] = c.Name
+			}
+			return nil, fmt.Errorf("column not found: %s (available columns: %s)", s, strings.Join(names, ", "))
+		}
+	}
+	return selected, nil
+}
+
+// writeTable writes rows in the given format.
+func writeTable(w io.Writer, format string, columns []tableColumn, rows []map[string]any, header bool) error {
+	switch format {
+	case "json":
+		out := make([]map[string]any, len(rows))
+		for i, row := range rows {
+			out[i] = make(map[string]any, len(columns))
+			for _, c := range columns {
+				out[i][c.Name] = row[c.Name]
+			}
+		}
+		return writeJSON(w, out)
+	case "csv", "tsv":
+		cw := csv.NewWriter(w)
+		if format == "tsv" {
+			cw.Comma = '\t'
+		}
+		if header {
+			_ = cw.Write(tableHeaders(columns))
+		}
+		for _, row := range rows {
+			_ = cw.Write(tableCells(columns, row))
+		}
+		cw.Flush()
+		return cw.Error()
+	case "plain":
+		if header {
+			fmt.Fprintln(w, strings.Join(tableHeaders(columns), "\t"))
+		}
+		for _, row := range rows {
+			fmt.Fprintln(w, strings.Join(tableCells(columns, row), "\t"))
+		}
+		return nil
+	case "table", "":
+		var lines [][]string
+		if header {
+			lines = append(lines, tableHeaders(columns))
+		}
+		for _, row := range rows {
+			lines = append(lines, tableCells(columns, row))
+		}
+		writeBoxTable(w, lines, header)
+		return nil
+	default:
+		return fmt.Errorf("invalid format: %s (supported formats: %s)", format, strings.Join(tableFormats, ", "))
+	}
+}
+
+func tableHeaders(columns []tableColumn) []string {
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.Header
+	}
+	return headers
+}
+
+func tableCells(columns []tableColumn, row map[string]any) []string {
+	cells := make([]string, len(columns))
+	for i, c := range columns {
+		cells[i] = formatValue(row[c.Name])
+	}
+	return cells
+}
+
+// writeBoxTable draws a table with borders, in the same style as the legacy CLI.
+// Cells may contain multiple lines.
+func writeBoxTable(w io.Writer, lines [][]string, header bool) {
+	if len(lines) == 0 {
+		return
+	}
+	widths := make([]int, len(lines[0]))
+	for _, cells := range lines {
+		for i, cell := range cells {
+			for _, l := range strings.Split(cell, "\n") {
+				widths[i] = max(widths[i], utf8.RuneCountInString(l))
+			}
+		}
+	}
+	var border strings.Builder
+	border.WriteString("+")
+	for _, width := range widths {
+		border.WriteString(strings.Repeat("-", width+2) + "+")
+	}
+	fmt.Fprintln(w, border.String())
+	for n, cells := range lines {
+		split := make([][]string, len(cells))
+		height := 1
+		for i, cell := range cells {
+			split[i] = strings.Split(cell, "\n")
+			height = max(height, len(split[i]))
+		}
+		for l := range height {
+			var b strings.Builder
+			b.WriteString("|")
+			for i := range cells {
+				var s string
+				if l < len(split[i]) {
+					s = split[i][l]
+				}
+				b.WriteString(" " + s + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(s)) + " |")
+			}
+			fmt.Fprintln(w, b.String())
+		}
+		if n == 0 && header && len(lines) > 1 {
+			fmt.Fprintln(w, border.String())
+		}
+	}
+	fmt.Fprintln(w, border.String())
+}
+
+// formatValue formats a value for display in a table cell.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t.Format(dateFormat)
+		}
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(dateFormat)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return formatValue(*v)
+	case []string:
+		return strings.Join(v, ", ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int, int64:
+		return fmt.Sprint(v)
+	case fmt.Stringer:
+		return v.String()
+	case []any:
+		if len(v) == 0 {
+			return ""
+		}
+	case map[string]any:
+		if len(v) == 0 {
+			return ""
+		}
+	}
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// writeJSON writes a value as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// renderProperties outputs a resource's properties, or a single property if
+// one is given (which may use dots to refer to nested properties).
+func renderProperties(cmd *cobra.Command, props map[string]any, property string) error {
+	delete(props, "_links")
+	delete(props, "_embedded")
+	format, _ := cmd.Flags().GetString("format")
+
+	if property != "" {
+		v, ok := nestedProperty(props, property)
+		if !ok {
+			return fmt.Errorf("property not found: %s", property)
+		}
+		if format == "json" {
+			return writeJSON(cmd.OutOrStdout(), v)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), formatValue(v))
+		return nil
+	}
+	if format == "json" {
+		return writeJSON(cmd.OutOrStdout(), props)
+	}
+
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rows := make([]map[string]any, len(keys))
+	for i, k := range keys {
+		rows[i] = map[string]any{"property": k, "value": props[k]}
+	}
+	columns := []tableColumn{{Name: "property", Header: "Property"}, {Name: "value", Header: "Value"}}
+	return writeTable(cmd.OutOrStdout(), format, columns, rows, true)
+}
+
+// nestedProperty finds a property by a dot-separated path.
+func nestedProperty(props map[string]any, property string) (any, bool) {
+	var v any = props
+	for _, key := range strings.Split(property, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
@@ -0,0 +1,258 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+var userColumns = []tableColumn{
+	{Name: "id", Header: "User ID"},
+	{Name: "email", Header: "Email address"},
+	{Name: "username", Header: "Username"},
+	{Name: "name", Header: "Name"},
+	{Name: "role", Header: "Project role"},
+	{Name: "production", Header: "Production"},
+	{Name: "staging", Header: "Staging"},
+	{Name: "development", Header: "Development"},
+	{Name: "permissions", Header: "Permissions"},
+	{Name: "granted_at", Header: "Granted"},
+	{Name: "updated_at", Header: "Updated"},
+}
+
+func newUserListCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "user:list",
+		Aliases: []string{"users"},
+		Short:   "List project users",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			projectID, err := selectProject(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			access, err := client.ListProjectUserAccess(cmd.Context(), projectID)
+			if err != nil {
+				return err
+			}
+			t := &table{
+				Columns:        userColumns,
+				DefaultColumns: []string{"email", "name", "role", "production", "staging", "development"},
+			}
+			for _, a := range access {
+				roles := a.Roles()
+				row := map[string]any{
+					"id":          a.UserID,
+					"role":        roles.Project,
+					"permissions": a.Permissions,
+					"granted_at":  a.GrantedAt,
+					"updated_at":  a.UpdatedAt,
+				}
+				for _, envType := range api.EnvironmentTypes {
+					row[envType] = roles.EnvironmentTypeRole(envType)
+				}
+				if a.User != nil {
+					row["email"] = a.User.Email
+					row["username"] = a.User.Username
+					row["name"] = strings.TrimSpace(a.User.FirstName + " " + a.User.LastName)
+				}
+				t.Rows = append(t.Rows, row)
+			}
+			sortRows(t.Rows, "email", false)
+
+			format, _ := cmd.Flags().GetString("format")
+			if format == "table" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Users on the project %s:\n", projectID)
+			}
+			return t.render(cmd)
+		},
+	}
+	addSelectionFlags(cmd, false)
+	addTableFlags(cmd, userColumns)
+	return cmd
+}
+
+// addRoleFlag adds the --role flag to a command.
+func addRoleFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSliceP("role", "r", nil, fmt.Sprintf(
+		"The user's project role (%s) or environment type role (e.g. production:viewer or staging:contributor). "+
+			"Environment types: %s. Environment type roles: %s, or none to remove the role",
+		strings.Join(api.ProjectRoleNames, " or "),
+		strings.Join(api.EnvironmentTypes, ", "),
+		strings.Join(api.EnvironmentRoleNames, ", ")))
+}
+
+// applyRoleFlag applies the roles given in the --role flag on top of existing roles.
+func applyRoleFlag(cmd *cobra.Command, roles api.ProjectRoles) (api.ProjectRoles, error) {
+	values, _ := cmd.Flags().GetStringSlice("role")
+	r := api.ProjectRoles{Project: roles.Project, EnvironmentTypes: maps.Clone(roles.EnvironmentTypes)}
+	if r.EnvironmentTypes == nil {
+		r.EnvironmentTypes = map[string]string{}
+	}
+	for _, v := range values {
+		envType, role, ok := strings.Cut(v, ":")
+		if !ok {
+			if !slices.Contains(api.ProjectRoleNames, v) {
+				return r, fmt.Errorf("invalid project role: %s (possible values: %s)",
+					v, strings.Join(api.ProjectRoleNames, ", "))
+			}
+			r.Project = v
+			continue
+		}
+		if !slices.Contains(api.EnvironmentTypes, envType) {
+			return r, fmt.Errorf("invalid environment type: %s (possible values: %s)",
+				envType, strings.Join(api.EnvironmentTypes, ", "))
+		}
+		if role == "none" {
+			delete(r.EnvironmentTypes, envType)
+			continue
+		}
+		if !slices.Contains(api.EnvironmentRoleNames, role) {
+			return r, fmt.Errorf("invalid role for the environment type %s: %s (possible values: %s, none)",
+				envType, role, strings.Join(api.EnvironmentRoleNames, ", "))
+		}
+		r.EnvironmentTypes[envType] = role
+	}
+	return r, nil
+}
+
+// findProjectUser finds a user on a project by email address or user ID.
+func findProjectUser(cmd *cobra.Command, client *api.Client, projectID, emailOrID string) (*api.ProjectAccess, error) {
+	access, err := client.ListProjectUserAccess(cmd.Context(), projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range access {
+		if a.UserID == emailOrID || (a.User != nil && strings.EqualFold(a.User.Email, emailOrID)) {
+			return a, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found on the project %s: %s", projectID, emailOrID)
+}
+
+func newUserAddCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user:add [email]",
+		Short: "Invite a user to a project",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			email := args[0]
+			if !strings.Contains(email, "@") {
+				return fmt.Errorf("invalid email address: %s", email)
+			}
+			roles, err := applyRoleFlag(cmd, api.ParseProjectRoles(nil))
+			if err != nil {
+				return err
+			}
+			projectID, err := selectProject(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			if _, err := client.InviteProjectUser(cmd.Context(), projectID, email, roles); err != nil {
+				if api.IsConflict(err) {
+					return fmt.Errorf("the user %s is already on the project %s: use user:update to change their roles",
+						email, projectID)
+				}
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Invited %s to the project %s with the role(s): %s\n",
+				email, projectID, strings.Join(roles.Permissions(), ", "))
+			return nil
+		},
+	}
+	addSelectionFlags(cmd, false)
+	addRoleFlag(cmd)
+	return cmd
+}
+
+func newUserUpdateCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user:update [email]",
+		Short: "Update a user's roles on a project",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("role") {
+				return errors.New("no changes were specified: use --role to set the user's roles")
+			}
+			projectID, err := selectProject(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			a, err := findProjectUser(cmd, client, projectID, args[0])
+			if err != nil {
+				return err
+			}
+			roles, err := applyRoleFlag(cmd, a.Roles())
+			if err != nil {
+				return err
+			}
+			permissions := roles.Permissions()
+			if slices.Equal(permissions, a.Roles().Permissions()) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "No changes were made to the roles of %s\n", args[0])
+				return nil
+			}
+			if _, err := client.UpdateProjectUserAccess(cmd.Context(), projectID, a.UserID, permissions); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Updated the roles of %s on the project %s: %s\n",
+				args[0], projectID, strings.Join(permissions, ", "))
+			return nil
+		},
+	}
+	addSelectionFlags(cmd, false)
+	addRoleFlag(cmd)
+	return cmd
+}
+
+func newUserDeleteCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "user:delete [email]",
+		Aliases: []string{"user:remove"},
+		Short:   "Remove a user from a project",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectID, err := selectProject(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			a, err := findProjectUser(cmd, client, projectID, args[0])
+			if err != nil {
+				return err
+			}
+			if !confirmAction(fmt.Sprintf("Are you sure you want to remove %s from the project %s?",
+				args[0], projectID)) {
+				return nil
+			}
+			if err := client.DeleteProjectUserAccess(cmd.Context(), projectID, a.UserID); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Removed %s from the project %s\n", args[0], projectID)
+			return nil
+		},
+	}
+	addSelectionFlags(cmd, false)
+	return cmd
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+var userAuditColumns = []tableColumn{
+	{Name: "user_id", Header: "User ID"},
+	{Name: "email", Header: "Email address"},
+	{Name: "name", Header: "Name"},
+	{Name: "project_id", Header: "Project ID"},
+	{Name: "project_title", Header: "Project title"},
+	{Name: "access", Header: "Access"},
+	{Name: "granted_at", Header: "Granted"},
+}
+
+func newUserAuditCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user:audit",
+		Short: "Report which users have admin access to production environments in an organization",
+		Long: "Cross-references the access of each organization user across all the organization's projects, " +
+			"and lists who has admin access to production environments.\n\n" +
+			"Access is either granted on a project (as a project admin, or with the production:admin role), " +
+			"or through the organization, as its owner or with the admin permission.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			org, err := selectOrganization(cmd, cnf, client)
+			if err != nil {
+				return err
+			}
+			members, err := client.ListOrganizationMembers(cmd.Context(), org.ID)
+			if err != nil {
+				return err
+			}
+			format, _ := cmd.Flags().GetString("format")
+			if format == "table" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Checking the access of %d user(s) in the organization %s...\n",
+					len(members), org.Name)
+			}
+
+			t := &table{
+				Columns:        userAuditColumns,
+				DefaultColumns: []string{"email", "project_id", "project_title", "access"},
+			}
+			for _, m := range members {
+				user := map[string]any{"user_id": m.UserID}
+				if m.User != nil {
+					user["email"] = m.User.Email
+					user["name"] = strings.TrimSpace(m.User.FirstName + " " + m.User.LastName)
+				}
+				if via := orgAdminAccess(m); via != "" {
+					row := maps.Clone(user)
+					row["project_title"] = "(all projects)"
+					row["access"] = via
+					t.Rows = append(t.Rows, row)
+				}
+
+				access, err := client.ListUserProjectAccess(cmd.Context(), m.UserID)
+				if err != nil {
+					return fmt.Errorf("could not list the projects of the user %s: %w", m.UserID, err)
+				}
+				for _, a := range access {
+					orgID := a.OrganizationID
+					if orgID == "" {
+						orgID = a.Project.OrganizationID
+					}
+					if orgID != org.ID {
+						continue
+					}
+					roles := a.Roles()
+					if roles.EnvironmentTypeRole("production") != api.EnvironmentRoleAdmin {
+						continue
+					}
+					via := "production:admin"
+					if roles.Project == api.ProjectRoleAdmin {
+						via = "project admin"
+					}
+					row := maps.Clone(user)
+					row["project_id"] = a.ProjectID
+					row["project_title"] = a.Project.Title
+					row["access"] = via
+					row["granted_at"] = a.GrantedAt
+					t.Rows = append(t.Rows, row)
+				}
+			}
+			sortRows(t.Rows, "project_id", false)
+			sortRows(t.Rows, "email", false)
+
+			if format == "table" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Users with admin access to production environments (%d):\n", len(t.Rows))
+			}
+			return t.render(cmd)
+		},
+	}
+	addOrgFlag(cmd)
+	addTableFlags(cmd, userAuditColumns)
+	return cmd
+}
+
+// orgAdminAccess describes how an organization member has admin access to
+// every project in the organization, if they do.
+func orgAdminAccess(m *api.OrganizationMember) string {
+	switch {
+	case m.Owner:
+		return "organization owner"
+	case slices.Contains(m.Permissions, api.OrgPermissionAdmin):
+		return "organization admin"
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+func TestUserCommands(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	testUserGrants(apiHandler)
+	list := func() string {
+		out, err := runCommand(t, newUserListCommand(cnf), "-p", "abc123", "--format", "csv")
+		require.NoError(t, err)
+		return out
+	}
+
+	assert.Equal(t, `Email address,Name,Project role,Production,Staging,Development
+alice@example.com,User alice,admin,admin,admin,admin
+bob@example.com,User bob,viewer,viewer,contributor,
+`, list())
+
+	_, err := runCommand(t, newUserUpdateCommand(cnf), "-p", "abc123", "bob@example.com",
+		"-r", "production:contributor,staging:none,development:admin")
+	require.NoError(t, err)
+	assert.Contains(t, list(), "bob@example.com,User bob,viewer,contributor,,admin\n")
+
+	_, err = runCommand(t, newUserUpdateCommand(cnf), "-p", "abc123", "bob", "--role", "production:owner")
+	assert.ErrorContains(t, err, "invalid role for the environment type production: owner")
+	_, err = runCommand(t, newUserUpdateCommand(cnf), "-p", "abc123", "bob", "--role", "preview:viewer")
+	assert.ErrorContains(t, err, "invalid environment type: preview")
+	_, err = runCommand(t, newUserUpdateCommand(cnf), "-p", "abc123", "carol", "--role", "admin")
+	assert.EqualError(t, err, "user not found on the project abc123: carol")
+
+	_, err = runCommand(t, newUserDeleteCommand(cnf), "-p", "abc123", "alice@example.com")
+	require.NoError(t, err)
+	assert.NotContains(t, list(), "alice")
+
+	_, err = runCommand(t, newUserAddCommand(cnf), "-p", "abc123", "carol@example.com",
+		"-r", "staging:admin", "-r", "development:contributor")
+	require.NoError(t, err)
+	invitations := apiHandler.ProjectInvitations("abc123")
+	require.Len(t, invitations, 1)
+	assert.Equal(t, "carol@example.com", invitations[0].Email)
+	assert.Equal(t, "viewer", invitations[0].Role)
+	assert.Equal(t, []mockapi.ProjectInvitationPermission{
+		{Type: "staging", Role: "admin"},
+		{Type: "development", Role: "contributor"},
+	}, invitations[0].Permissions)
+
+	_, err = runCommand(t, newUserAddCommand(cnf), "-p", "abc123", "bob@example.com")
+	assert.ErrorContains(t, err, "the user bob@example.com is already on the project abc123")
+}
+
+func TestUserAudit(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	testOrgs(apiHandler)
+	testUserGrants(apiHandler)
+	apiHandler.SetOrgMembers("org-1", []*mockapi.OrgMember{
+		{ID: "m1", OrganizationID: "org-1", UserID: "my-user-id", Owner: true, Permissions: []string{"admin"}},
+		{ID: "m2", OrganizationID: "org-1", UserID: "alice", Permissions: []string{"projects:list"}},
+		{ID: "m3", OrganizationID: "org-1", UserID: "bob", Permissions: []string{"projects:list"}},
+	})
+
+	out, err := runCommand(t, newUserAuditCommand(cnf), "-o", "mine", "--format", "csv")
+	require.NoError(t, err)
+	assert.Equal(t, `Email address,Project ID,Project title,Access
+alice@example.com,abc123,Site,project admin
+bob@example.com,def456,Shop,production:admin
+my-user-id@example.com,,(all projects),organization owner
+`, out)
+}
@@ -0,0 +1,213 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+// hiddenValue is displayed in place of a sensitive variable's value, which cannot be read.
+const hiddenValue = "[Hidden: sensitive value]"
+
+// addVariableLevelFlag adds the --level flag to a variable command.
+func addVariableLevelFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("level", "l", "", "The variable level ('project', 'environment', 'p' or 'e')")
+}
+
+// variableLevelFlag returns the normalized value of the --level flag, which may be empty.
+func variableLevelFlag(cmd *cobra.Command) (string, error) {
+	level, _ := cmd.Flags().GetString("level")
+	switch level {
+	case "":
+		return "", nil
+	case "p", api.VariableLevelProject:
+		return api.VariableLevelProject, nil
+	case "e", api.VariableLevelEnvironment:
+		return api.VariableLevelEnvironment, nil
+	}
+	return "", fmt.Errorf("invalid level: %s (it must be 'project' or 'environment')", level)
+}
+
+// selectVariableScope returns the project and (depending on the level) the
+// environment for a variable command. If the level is not specified, the
+// environment is optional: an empty environment ID is returned if none can be selected.
+func selectVariableScope(cmd *cobra.Command, cnf *config.Config, client *api.Client, level string) (
+	projectID, environmentID string, err error) {
+	projectID, err = selectProject(cmd, cnf)
+	if err != nil || level == api.VariableLevelProject {
+		return projectID, "", err
+	}
+	env, err := selectEnvironment(cmd, cnf, client, projectID)
+	if err != nil {
+		if level == "" {
+			debugLog("Only project-level variables are available: %s", err)
+			return projectID, "", nil
+		}
+		return "", "", err
+	}
+	return projectID, env.ID, nil
+}
+
+// findVariable finds a variable by name.
+//
+// If the level is not specified, then both levels are checked, and the
+// environment-level variable takes precedence (as it overrides the
+// project-level one at runtime). But if unique is true, a variable which
+// exists at both levels is an error, as it is ambiguous which one to change.
+func findVariable(cmd *cobra.Command, client *api.Client, projectID, environmentID, name, level string,
+	unique bool) (*api.Variable, error) {
+	var found []*api.Variable
+	if level != api.VariableLevelProject && environmentID != "" {
+		v, err := client.GetVariable(cmd.Context(), projectID, environmentID, name)
+		if err != nil && !api.IsNotFound(err) {
+			return nil, err
+		}
+		if v != nil {
+			found = append(found, v)
+		}
+	}
+	if level != api.VariableLevelEnvironment {
+		v, err := client.GetVariable(cmd.Context(), projectID, "", name)
+		if err != nil && !api.IsNotFound(err) {
+			return nil, err
+		}
+		if v != nil {
+			found = append(found, v)
+		}
+	}
+	switch {
+	case len(found) == 0:
+		return nil, fmt.Errorf("variable not found: %s", name)
+	case len(found) > 1 && unique:
+		return nil, fmt.Errorf("the variable %s exists at both the project and environment levels: "+
+			"use --level to specify which one", name)
+	}
+	return found[0], nil
+}
+
+// addVariableFieldFlags adds the flags for a variable's properties. As in the
+// legacy CLI, the boolean flags need a value, e.g. "--sensitive true" or
+// "--visible-build=false".
+func addVariableFieldFlags(cmd *cobra.Command) {
+	cmd.Flags().String("value", "", "The variable's value")
+	addBoolValueFlag(cmd, "json", false, "Whether the variable is JSON-formatted")
+	addBoolValueFlag(cmd, "sensitive", false, "Whether the variable's value is sensitive (it cannot be read once set)")
+	addBoolValueFlag(cmd, "visible-build", true, "Whether the variable is visible at build time")
+	addBoolValueFlag(cmd, "visible-runtime", true, "Whether the variable is visible at runtime")
+	addBoolValueFlag(cmd, "enabled", true,
+		"Whether the variable is enabled on the environment (environment-level only)")
+	addBoolValueFlag(cmd, "inheritable", true,
+		"Whether the variable is inherited by child environments (environment-level only)")
+}
+
+// boolValue is a boolean flag value which, unlike a pflag bool, needs a value,
+// given either as a separate argument ("--flag false") or after "=".
+type boolValue bool
+
+func (b *boolValue) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return errors.New("expected true or false")
+	}
+	*b = boolValue(v)
+	return nil
+}
+
+func (b *boolValue) String() string { return strconv.FormatBool(bool(*b)) }
+
+func (b *boolValue) Type() string { return "true|false" }
+
+// addBoolValueFlag adds a boolean flag which needs a value (see boolValue).
+func addBoolValueFlag(cmd *cobra.Command, name string, value bool, usage string) {
+	b := boolValue(value)
+	cmd.Flags().Var(&b, name, usage)
+}
+
+var (
+	variableFieldFlags = map[string]string{
+		"value":           "value",
+		"json":            "is_json",
+		"sensitive":       "is_sensitive",
+		"visible-build":   "visible_build",
+		"visible-runtime": "visible_runtime",
+		"enabled":         "is_enabled",
+		"inheritable":     "is_inheritable",
+	}
+	environmentOnlyFlags = []string{"enabled", "inheritable"}
+)
+
+// variableFields builds the API fields for a variable from flags. If all is
+// false, only the flags that were explicitly set are included.
+func variableFields(cmd *cobra.Command, level string, all bool) (map[string]any, error) {
+	flags := cmd.Flags()
+	if level == api.VariableLevelProject {
+		for _, name := range environmentOnlyFlags {
+			if flags.Changed(name) {
+				return nil, fmt.Errorf("the --%s flag is only available for environment-level variables", name)
+			}
+		}
+	}
+	fields := make(map[string]any)
+	for flagName, field := range variableFieldFlags {
+		if level == api.VariableLevelProject && (field == "is_enabled" || field == "is_inheritable") {
+			continue
+		}
+		if flags.Lookup(flagName) == nil || (!all && !flags.Changed(flagName)) {
+			continue
+		}
+		if flagName == "value" {
+			fields[field], _ = flags.GetString(flagName)
+		} else {
+			// This reads both pflag bool flags and boolValue flags.
+			fields[field], _ = strconv.ParseBool(flags.Lookup(flagName).Value.String())
+		}
+	}
+	if isJSON, _ := fields["is_json"].(bool); isJSON {
+		if value, ok := fields["value"].(string); ok {
+			if err := validateJSONValue(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fields, nil
+}
+
+// validateJSONValue checks the value of a JSON-formatted variable.
+func validateJSONValue(value string) error {
+	if !json.Valid([]byte(value)) {
+		return fmt.Errorf("the value is not valid JSON: %s", value)
+	}
+	return nil
+}
+
+// variableValue returns a variable's value for display.
+func variableValue(v *api.Variable) string {
+	if v.IsSensitive {
+		return hiddenValue
+	}
+	return v.Value
+}
+
+// describeVariableScope describes where a variable is, for messages.
+func describeVariableScope(projectID, environmentID string) string {
+	if environmentID == "" {
+		return "the project " + projectID
+	}
+	return "the environment " + environmentID
+}
+
+// checkInherited returns an error if a variable cannot be changed because it
+// is inherited from a parent environment.
+func checkInherited(v *api.Variable, action string) error {
+	if v.Inherited {
+		return fmt.Errorf("the variable %s is inherited from a parent environment, so it cannot be %sd here: "+
+			"change it on the parent, or override it with variable:create --level environment", v.Name, action)
+	}
+	return nil
+}
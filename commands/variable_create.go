@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+func newVariableCreateCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "variable:create [name]",
+		Short: "Create a variable",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVariableCreate(cmd, cnf, args[0])
+		},
+	}
+	addSelectionFlags(cmd, true)
+	addVariableLevelFlag(cmd)
+	addVariableFieldFlags(cmd)
+	cmd.Flags().BoolP("update", "u", false, "Update the variable if it already exists")
+	addWaitFlags(cmd)
+	return cmd
+}
+
+func runVariableCreate(cmd *cobra.Command, cnf *config.Config, name string) error {
+	level, err := variableLevelFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if level == "" {
+		return errors.New("the --level flag is required when creating a variable")
+	}
+	if !cmd.Flags().Changed("value") {
+		return errors.New("the --value flag is required when creating a variable")
+	}
+	client, err := newAPIClient(cmd, cnf)
+	if err != nil {
+		return err
+	}
+	projectID, environmentID, err := selectVariableScope(cmd, cnf, client, level)
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.GetVariable(cmd.Context(), projectID, environmentID, name)
+	if err != nil && !api.IsNotFound(err) {
+		return err
+	}
+	if existing != nil && !existing.Inherited {
+		if update, _ := cmd.Flags().GetBool("update"); !update {
+			return fmt.Errorf("the variable %s already exists on %s: use --update to change it",
+				name, describeVariableScope(projectID, environmentID))
+		}
+		fields, err := variableFields(cmd, level, false)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Updating variable %s on %s\n",
+			name, describeVariableScope(projectID, environmentID))
+		activities, err := client.UpdateVariable(cmd.Context(), projectID, environmentID, name, fields)
+		if err != nil {
+			return err
+		}
+		return variableChanged(cmd, client, activities)
+	}
+
+	fields, err := variableFields(cmd, level, true)
+	if err != nil {
+		return err
+	}
+	fields["name"] = name
+	fmt.Fprintf(cmd.ErrOrStderr(), "Creating variable %s on %s\n", name, describeVariableScope(projectID, environmentID))
+	activities, err := client.CreateVariable(cmd.Context(), projectID, environmentID, fields)
+	if err != nil {
+		return err
+	}
+	return variableChanged(cmd, client, activities)
+}
+
+func newVariableUpdateCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "variable:update [name]",
+		Short: "Update a variable",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			level, err := variableLevelFlag(cmd)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			projectID, environmentID, err := selectVariableScope(cmd, cnf, client, level)
+			if err != nil {
+				return err
+			}
+			v, err := findVariable(cmd, client, projectID, environmentID, args[0], level, true)
+			if err != nil {
+				return err
+			}
+			if err := checkInherited(v, "update"); err != nil {
+				return err
+			}
+			if v.Level == api.VariableLevelProject {
+				environmentID = ""
+			}
+			fields, err := variableFields(cmd, v.Level, false)
+			if err != nil {
+				return err
+			}
+			if len(fields) == 0 {
+				return errors.New("no changes were specified")
+			}
+			if isJSON, _ := fields["is_json"].(bool); isJSON && !v.IsSensitive {
+				if _, ok := fields["value"]; !ok {
+					// Validate the existing value if only the JSON flag changed.
+					if err := validateJSONValue(v.Value); err != nil {
+						return err
+					}
+				}
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Updating variable %s on %s\n",
+				v.Name, describeVariableScope(projectID, environmentID))
+			activities, err := client.UpdateVariable(cmd.Context(), projectID, environmentID, v.Name, fields)
+			if err != nil {
+				return err
+			}
+			return variableChanged(cmd, client, activities)
+		},
+	}
+	addSelectionFlags(cmd, true)
+	addVariableLevelFlag(cmd)
+	addVariableFieldFlags(cmd)
+	addWaitFlags(cmd)
+	return cmd
+}
+
+// variableChanged reports the result of a variable change, waiting for any
+// activities that it started.
+func variableChanged(cmd *cobra.Command, client *api.Client, activities []*api.Activity) error {
+	if len(activities) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "The change will take effect when the environment is next redeployed.")
+		return nil
+	}
+	if shouldWait(cmd) {
+		return waitForActivities(cmd, client, activities)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+func newVariableDeleteCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "variable:delete [name]",
+		Short: "Delete a variable",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			level, err := variableLevelFlag(cmd)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			projectID, environmentID, err := selectVariableScope(cmd, cnf, client, level)
+			if err != nil {
+				return err
+			}
+			v, err := findVariable(cmd, client, projectID, environmentID, args[0], level, true)
+			if err != nil {
+				return err
+			}
+			if err := checkInherited(v, "delete"); err != nil {
+				return err
+			}
+			if v.Level == api.VariableLevelProject {
+				environmentID = ""
+			}
+			scope := describeVariableScope(projectID, environmentID)
+			if !confirmAction(fmt.Sprintf("Are you sure you want to delete the variable %s from %s?", v.Name, scope)) {
+				return nil
+			}
+			activities, err := client.DeleteVariable(cmd.Context(), projectID, environmentID, v.Name)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Deleted variable %s from %s\n", v.Name, scope)
+			return variableChanged(cmd, client, activities)
+		},
+	}
+	addSelectionFlags(cmd, true)
+	addVariableLevelFlag(cmd)
+	addWaitFlags(cmd)
+	return cmd
+}
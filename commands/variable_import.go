@@ -0,0 +1,293 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+func newVariableImportCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "variable:import [file]",
+		Short: "Import variables from a .env file",
+		Long: "Import variables from a .env file (by default, .env in the current directory).\n\n" +
+			"A preview of the changes is shown before they are applied. Existing variables are updated " +
+			"only if their value differs, or if a flag (e.g. --sensitive) is given which changes them. " +
+			"Variables are never deleted.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filename := ".env"
+			if len(args) > 0 {
+				filename = args[0]
+			}
+			return runVariableImport(cmd, cnf, filename)
+		},
+	}
+	addSelectionFlags(cmd, true)
+	addVariableLevelFlag(cmd)
+	cmd.Flags().String("prefix", "env:",
+		"A prefix to add to each variable name ('env:' exposes them as environment variables)")
+	cmd.Flags().Bool("sensitive", false, "Mark the variables as sensitive")
+	cmd.Flags().Bool("visible-build", true, "Make the variables visible at build time")
+	cmd.Flags().Bool("visible-runtime", true, "Make the variables visible at runtime")
+	cmd.Flags().Bool("dry-run", false, "Only show the changes that would be made")
+	addWaitFlags(cmd)
+	return cmd
+}
+
+// variableChange is a change to be made by an import.
+type variableChange struct {
+	Name     string
+	Value    string
+	Existing *api.Variable // nil if the variable is to be created.
+
+	// Fields are the other fields to change on an existing variable, from flags.
+	Fields map[string]any
+}
+
+func runVariableImport(cmd *cobra.Command, cnf *config.Config, filename string) error {
+	level, err := variableLevelFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if level == "" {
+		return errors.New("the --level flag is required when importing variables")
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	entries, err := parseDotEnv(f)
+	if err != nil {
+		return fmt.Errorf("could not parse %s: %w", filename, err)
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no variables found in %s", filename)
+	}
+
+	client, err := newAPIClient(cmd, cnf)
+	if err != nil {
+		return err
+	}
+	projectID, environmentID, err := selectVariableScope(cmd, cnf, client, level)
+	if err != nil {
+		return err
+	}
+	existing, err := client.ListVariables(cmd.Context(), projectID, environmentID)
+	if err != nil {
+		return err
+	}
+	existingByName := make(map[string]*api.Variable, len(existing))
+	for _, v := range existing {
+		// An inherited variable is overridden by creating a new one.
+		if !v.Inherited {
+			existingByName[v.Name] = v
+		}
+	}
+
+	// New variables get every flag's value (or default), as with variable:create. Existing
+	// variables only get the flags which were given, as with variable:update.
+	createFields, err := variableFields(cmd, level, true)
+	if err != nil {
+		return err
+	}
+	updateFields, err := variableFields(cmd, level, false)
+	if err != nil {
+		return err
+	}
+
+	prefix, _ := cmd.Flags().GetString("prefix")
+	var changes []variableChange
+	var unchanged int
+	for _, e := range entries {
+		c := variableChange{Name: prefix + e.Key, Value: e.Value, Existing: existingByName[prefix+e.Key]}
+		if c.Existing != nil {
+			c.Fields = changedVariableFields(c.Existing, updateFields)
+			if len(c.Fields) == 0 && !c.Existing.IsSensitive && c.Existing.Value == c.Value {
+				unchanged++
+				continue
+			}
+		}
+		changes = append(changes, c)
+	}
+
+	sensitive, _ := cmd.Flags().GetBool("sensitive")
+	scope := describeVariableScope(projectID, environmentID)
+	printVariableChanges(cmd.OutOrStdout(), scope, changes, unchanged, sensitive)
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun || len(changes) == 0 {
+		return nil
+	}
+	if !confirmAction(fmt.Sprintf("Are you sure you want to apply %d change(s)?", len(changes))) {
+		return nil
+	}
+
+	stderr := cmd.ErrOrStderr()
+	var activities []*api.Activity
+	for _, c := range changes {
+		var started []*api.Activity
+		if c.Existing != nil {
+			fields := maps.Clone(c.Fields)
+			fields["value"] = c.Value
+			started, err = client.UpdateVariable(cmd.Context(), projectID, environmentID, c.Name, fields)
+		} else {
+			fields := maps.Clone(createFields)
+			fields["name"], fields["value"] = c.Name, c.Value
+			started, err = client.CreateVariable(cmd.Context(), projectID, environmentID, fields)
+		}
+		if err != nil {
+			return fmt.Errorf("could not import the variable %s: %w", c.Name, err)
+		}
+		activities = append(activities, started...)
+	}
+	fmt.Fprintf(stderr, "Imported %d variable(s) into %s\n", len(changes), scope)
+	return variableChanged(cmd, client, activities)
+}
+
+// changedVariableFields returns the fields which would change an existing
+// variable. A sensitive variable cannot be made non-sensitive.
+func changedVariableFields(v *api.Variable, fields map[string]any) map[string]any {
+	current := map[string]any{
+		"is_json":         v.IsJSON,
+		"is_sensitive":    v.IsSensitive,
+		"visible_build":   v.VisibleBuild,
+		"visible_runtime": v.VisibleRuntime,
+		"is_enabled":      v.IsEnabled,
+		"is_inheritable":  v.IsInheritable,
+	}
+	changed := make(map[string]any)
+	for field, value := range fields {
+		if cur, ok := current[field]; !ok || cur == value || (field == "is_sensitive" && v.IsSensitive) {
+			continue
+		}
+		changed[field] = value
+	}
+	return changed
+}
+
+// printVariableChanges prints a preview of the changes to be made by an import.
+func printVariableChanges(w io.Writer, scope string, changes []variableChange, unchanged int, sensitive bool) {
+	mask := func(value string, isSensitive bool) string {
+		if isSensitive {
+			return "******"
+		}
+		return value
+	}
+	fmt.Fprintf(w, "Changes to variables on %s:\n", scope)
+	for _, c := range changes {
+		if c.Existing == nil {
+			fmt.Fprintln(w, color.GreenString("+ %s = %s", c.Name, mask(c.Value, sensitive)))
+			continue
+		}
+		line := fmt.Sprintf("~ %s: %s → %s", c.Name,
+			mask(c.Existing.Value, c.Existing.IsSensitive), mask(c.Value, sensitive || c.Existing.IsSensitive))
+		if len(c.Fields) > 0 {
+			var fieldChanges []string
+			for _, field := range slices.Sorted(maps.Keys(c.Fields)) {
+				fieldChanges = append(fieldChanges, fmt.Sprintf("%s: %v → %v", field, !c.Fields[field].(bool), c.Fields[field]))
+			}
+			line += " (" + strings.Join(fieldChanges, ", ") + ")"
+		}
+		fmt.Fprintln(w, color.YellowString("%s", line))
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes")
+	}
+	if unchanged > 0 {
+		fmt.Fprintf(w, "%d variable(s) unchanged\n", unchanged)
+	}
+}
+
+// dotEnvEntry is a key/value pair from a .env file.
+type dotEnvEntry struct {
+	Key   string
+	Value string
+}
+
+var dotEnvLine = regexp.MustCompile(`^(?:export\s+)?([A-Za-z_][A-Za-z0-9_.]*)\s*=\s*(.*)$`)
+
+// parseDotEnv parses a .env file, returning its entries in order. Comments,
+// "export" prefixes, and single- or double-quoted values are supported.
+func parseDotEnv(r io.Reader) ([]dotEnvEntry, error) {
+	var entries []dotEnvEntry
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		m := dotEnvLine.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("invalid line %d: %s", lineNo, line)
+		}
+		value, err := parseDotEnvValue(m[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value on line %d: %w", lineNo, err)
+		}
+		entries = append(entries, dotEnvEntry{Key: m[1], Value: value})
+	}
+	return entries, scanner.Err()
+}
+
+func parseDotEnvValue(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		var b strings.Builder
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '"':
+				return b.String(), checkDotEnvTrailing(s[i+1:])
+			case '\\':
+				if i+1 == len(s) {
+					return "", errors.New("unterminated escape sequence")
+				}
+				i++
+				switch s[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(s[i])
+				}
+			default:
+				b.WriteByte(s[i])
+			}
+		}
+		return "", errors.New("unterminated double quote")
+	case strings.HasPrefix(s, `'`):
+		end := strings.IndexByte(s[1:], '\'')
+		if end == -1 {
+			return "", errors.New("unterminated single quote")
+		}
+		return s[1 : end+1], checkDotEnvTrailing(s[end+2:])
+	}
+	// Unquoted values end at an inline comment.
+	if i := strings.Index(s, " #"); i != -1 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s), nil
+}
+
+// checkDotEnvTrailing checks that nothing but a comment follows a quoted value.
+func checkDotEnvTrailing(s string) error {
+	s = strings.TrimSpace(s)
+	if s != "" && !strings.HasPrefix(s, "#") {
+		return fmt.Errorf("unexpected characters after the closing quote: %s", s)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+var variableListColumns = []tableColumn{
+	{Name: "name", Header: "Name"},
+	{Name: "level", Header: "Level"},
+	{Name: "value", Header: "Value"},
+	{Name: "is_enabled", Header: "Enabled"},
+	{Name: "inherited", Header: "Inherited"},
+	{Name: "is_inheritable", Header: "Inheritable"},
+	{Name: "is_sensitive", Header: "Sensitive"},
+	{Name: "is_json", Header: "JSON"},
+	{Name: "visible_build", Header: "Build"},
+	{Name: "visible_runtime", Header: "Runtime"},
+	{Name: "created_at", Header: "Created"},
+	{Name: "updated_at", Header: "Updated"},
+}
+
+func newVariableListCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "variable:list",
+		Aliases: []string{"variables", "var"},
+		Short:   "List variables",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runVariableList(cmd, cnf)
+		},
+	}
+	addSelectionFlags(cmd, true)
+	addVariableLevelFlag(cmd)
+	addTableFlags(cmd, variableListColumns)
+	return cmd
+}
+
+func runVariableList(cmd *cobra.Command, cnf *config.Config) error {
+	level, err := variableLevelFlag(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient(cmd, cnf)
+	if err != nil {
+		return err
+	}
+	projectID, environmentID, err := selectVariableScope(cmd, cnf, client, level)
+	if err != nil {
+		return err
+	}
+
+	var vars []*api.Variable
+	if level != api.VariableLevelEnvironment {
+		projectVars, err := client.ListVariables(cmd.Context(), projectID, "")
+		if err != nil {
+			return err
+		}
+		vars = append(vars, projectVars...)
+	}
+	if environmentID != "" {
+		envVars, err := client.ListVariables(cmd.Context(), projectID, environmentID)
+		if err != nil {
+			return err
+		}
+		vars = append(vars, envVars...)
+	}
+
+	stderr := cmd.ErrOrStderr()
+	if len(vars) == 0 {
+		fmt.Fprintln(stderr, "No variables found.")
+		return nil
+	}
+
+	t := &table{Columns: variableListColumns, DefaultColumns: []string{"name", "level", "value", "is_enabled"}}
+	for _, v := range vars {
+		t.Rows = append(t.Rows, variableRow(v))
+	}
+	sortRows(t.Rows, "name", false)
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "table" {
+		fmt.Fprintf(stderr, "Variables on %s:\n", describeVariableScope(projectID, environmentID))
+	}
+	if err := t.render(cmd); err != nil {
+		return err
+	}
+	if format == "table" {
+		fmt.Fprintln(stderr)
+		fmt.Fprintf(stderr, "To view a variable, run: %s variable:get [name]\n", cnf.Application.Executable)
+	}
+	return nil
+}
+
+func variableRow(v *api.Variable) map[string]any {
+	row := map[string]any{
+		"name":            v.Name,
+		"level":           v.Level,
+		"value":           variableValue(v),
+		"is_sensitive":    v.IsSensitive,
+		"is_json":         v.IsJSON,
+		"visible_build":   v.VisibleBuild,
+		"visible_runtime": v.VisibleRuntime,
+		"created_at":      v.CreatedAt,
+		"updated_at":      v.UpdatedAt,
+	}
+	if v.Level == api.VariableLevelEnvironment {
+		row["is_enabled"] = v.IsEnabled
+		row["inherited"] = v.Inherited
+		row["is_inheritable"] = v.IsInheritable
+	} else {
+		// These properties do not apply to project-level variables.
+		row["is_enabled"] = true
+		row["inherited"] = ""
+		row["is_inheritable"] = ""
+	}
+	return row
+}
+
+func newVariableGetCommand(cnf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "variable:get [name] [property]",
+		Aliases: []string{"vget"},
+		Short:   "View a variable",
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			level, err := variableLevelFlag(cmd)
+			if err != nil {
+				return err
+			}
+			client, err := newAPIClient(cmd, cnf)
+			if err != nil {
+				return err
+			}
+			projectID, environmentID, err := selectVariableScope(cmd, cnf, client, level)
+			if err != nil {
+				return err
+			}
+			v, err := findVariable(cmd, client, projectID, environmentID, args[0], level, false)
+			if err != nil {
+				return err
+			}
+			if v.Level == api.VariableLevelProject {
+				environmentID = ""
+			}
+			props, err := client.GetVariableProperties(cmd.Context(), projectID, environmentID, v.Name)
+			if err != nil {
+				return err
+			}
+			props["level"] = v.Level
+			var property string
+			if len(args) > 1 {
+				property = args[1]
+			}
+			return renderProperties(cmd, props, property)
+		},
+	}
+	addSelectionFlags(cmd, true)
+	addVariableLevelFlag(cmd)
+	addPropertyFlags(cmd)
+	return cmd
+}
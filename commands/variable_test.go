@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariableList(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetEnvironments(testEnvironments("abc123"))
+	testVariables(apiHandler, "abc123", "main")
+
+	out, err := runCommand(t, newVariableListCommand(cnf), "-p", "abc123", "-e", "main",
+		"--format", "csv", "--columns", "name,level,value,inherited")
+	require.NoError(t, err)
+	assert.Equal(t, `Name,Level,Value,Inherited
+env:FROM_PARENT,environment,parent-value,true
+env:SECRET,project,[Hidden: sensitive value],
+env:SHARED,project,project-value,
+env:SHARED,environment,env-value,false
+`, out)
+
+	// Without an environment, only project-level variables are listed.
+	out, err = runCommand(t, newVariableListCommand(cnf), "-p", "abc123", "--format", "csv", "--columns", "name")
+	require.NoError(t, err)
+	assert.Equal(t, "Name\nenv:SECRET\nenv:SHARED\n", out)
+}
+
+func TestVariableGet(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetEnvironments(testEnvironments("abc123"))
+	testVariables(apiHandler, "abc123", "main")
+
+	// The environment-level variable takes precedence.
+	out, err := runCommand(t, newVariableGetCommand(cnf), "-p", "abc123", "-e", "main", "env:SHARED", "value")
+	require.NoError(t, err)
+	assert.Equal(t, "env-value\n", out)
+
+	out, err = runCommand(t, newVariableGetCommand(cnf), "-p", "abc123", "-e", "main", "-l", "p",
+		"env:SHARED", "--format", "json")
+	require.NoError(t, err)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &props))
+	assert.Equal(t, "project-value", props["value"])
+	assert.Equal(t, "project", props["level"])
+
+	_, err = runCommand(t, newVariableGetCommand(cnf), "-p", "abc123", "env:MISSING")
+	assert.EqualError(t, err, "variable not found: env:MISSING")
+}
+
+func TestVariableCreateUpdateDelete(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetEnvironments(testEnvironments("abc123"))
+	testVariables(apiHandler, "abc123", "main")
+	get := func(args ...string) string {
+		out, err := runCommand(t, newVariableGetCommand(cnf), append([]string{"-p", "abc123", "-e", "main"}, args...)...)
+		require.NoError(t, err)
+		return out
+	}
+
+	_, err := runCommand(t, newVariableCreateCommand(cnf), "-p", "abc123", "env:NEW", "--value", "x")
+	assert.EqualError(t, err, "the --level flag is required when creating a variable")
+
+	_, err = runCommand(t, newVariableCreateCommand(cnf), "-p", "abc123", "-l", "project", "env:NEW",
+		"--value", "{", "--json", "true")
+	assert.EqualError(t, err, "the value is not valid JSON: {")
+
+	_, err = runCommand(t, newVariableCreateCommand(cnf), "-p", "abc123", "-l", "project", "env:NEW",
+		"--value", "x", "--enabled=false")
+	assert.EqualError(t, err, "the --enabled flag is only available for environment-level variables")
+
+	_, err = runCommand(t, newVariableCreateCommand(cnf), "-p", "abc123", "-l", "project", "env:NEW",
+		"--value", `{"a": 1}`, "--json", "true", "--visible-build=false")
+	require.NoError(t, err)
+	assert.Equal(t, "false\n", get("env:NEW", "visible_build"))
+	assert.Equal(t, "true\n", get("env:NEW", "is_json"))
+
+	// Boolean flags take a value, as in the legacy CLI.
+	_, err = runCommand(t, newVariableUpdateCommand(cnf), "-p", "abc123", "-l", "p", "env:NEW",
+		"--visible-runtime", "false", "--visible-build", "true")
+	require.NoError(t, err)
+	assert.Equal(t, "false\n", get("env:NEW", "visible_runtime"))
+	assert.Equal(t, "true\n", get("env:NEW", "visible_build"))
+	_, err = runCommand(t, newVariableUpdateCommand(cnf), "-p", "abc123", "-l", "p", "env:NEW", "--sensitive", "yes")
+	assert.ErrorContains(t, err, `invalid argument "yes" for "--sensitive" flag: expected true or false`)
+
+	_, err = runCommand(t, newVariableCreateCommand(cnf), "-p", "abc123", "-l", "project", "env:NEW", "--value", "y")
+	assert.ErrorContains(t, err, "the variable env:NEW already exists on the project abc123")
+
+	// An ambiguous variable can only be updated by specifying its level.
+	_, err = runCommand(t, newVariableUpdateCommand(cnf), "-p", "abc123", "-e", "main", "env:SHARED", "--value", "z")
+	assert.ErrorContains(t, err, "exists at both the project and environment levels")
+	_, err = runCommand(t, newVariableUpdateCommand(cnf), "-p", "abc123", "-e", "main", "-l", "e",
+		"env:SHARED", "--value", "z")
+	require.NoError(t, err)
+	assert.Equal(t, "z\n", get("env:SHARED", "value"))
+
+	_, err = runCommand(t, newVariableUpdateCommand(cnf), "-p", "abc123", "-e", "main", "env:SHARED", "-l", "e")
+	assert.EqualError(t, err, "no changes were specified")
+
+	// Inherited variables cannot be changed on the child environment.
+	_, err = runCommand(t, newVariableDeleteCommand(cnf), "-p", "abc123", "-e", "main", "env:FROM_PARENT")
+	assert.ErrorContains(t, err, "is inherited from a parent environment, so it cannot be deleted here")
+
+	_, err = runCommand(t, newVariableDeleteCommand(cnf), "-p", "abc123", "-e", "main", "-l", "p", "env:SHARED")
+	require.NoError(t, err)
+	assert.Equal(t, "z\n", get("env:SHARED", "value"))
+	_, err = runCommand(t, newVariableGetCommand(cnf), "-p", "abc123", "-l", "p", "env:SHARED")
+	assert.EqualError(t, err, "variable not found: env:SHARED")
+}
+
+func TestVariableImport(t *testing.T) {
+	cnf, apiHandler := newTestAPI(t)
+	apiHandler.SetEnvironments(testEnvironments("abc123"))
+	testVariables(apiHandler, "abc123", "main")
+
+	file := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(file, []byte(strings.Join([]string{
+		"# A comment",
+		"SHARED=project-value",
+		"export SECRET='new-secret'",
+		`ADDED="hello\nworld" # with a comment`,
+	}, "\n")), 0o600))
+
+	out, err := runCommand(t, newVariableImportCommand(cnf), "-p", "abc123", "-l", "project", file, "--dry-run")
+	require.NoError(t, err)
+	assert.Equal(t, "Changes to variables on the project abc123:\n"+
+		"~ env:SECRET: ****** → ******\n"+
+		"+ env:ADDED = hello\nworld\n"+
+		"1 variable(s) unchanged\n", out)
+
+	_, err = runCommand(t, newVariableImportCommand(cnf), "-p", "abc123", "-l", "project", file)
+	require.NoError(t, err)
+	out, err = runCommand(t, newVariableGetCommand(cnf), "-p", "abc123", "env:ADDED", "value")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\nworld\n", out)
+
+	// Flags are also applied to existing variables.
+	out, err = runCommand(t, newVariableImportCommand(cnf), "-p", "abc123", "-l", "project", file,
+		"--visible-build=false", "--dry-run")
+	require.NoError(t, err)
+	assert.Equal(t, "Changes to variables on the project abc123:\n"+
+		"~ env:SHARED: project-value → project-value (visible_build: true → false)\n"+
+		"~ env:SECRET: ****** → ****** (visible_build: true → false)\n"+
+		"~ env:ADDED: hello\nworld → hello\nworld (visible_build: true → false)\n", out)
+
+	_, err = runCommand(t, newVariableImportCommand(cnf), "-p", "abc123", "-l", "project", file,
+		"--visible-build=false", "--sensitive")
+	require.NoError(t, err)
+	out, err = runCommand(t, newVariableGetCommand(cnf), "-p", "abc123", "env:SHARED", "--format", "csv")
+	require.NoError(t, err)
+	assert.Contains(t, out, "is_sensitive,true\n")
+	assert.Contains(t, out, "visible_build,false\n")
+}
+
+func TestParseDotEnv(t *testing.T) {
+	entries, err := parseDotEnv(strings.NewReader(`
+# Comment
+A=1
+export B = two words # comment
+C="quoted \"value\" # not a comment"
+D='single $quoted'
+E=
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []dotEnvEntry{
+		{"A", "1"},
+		{"B", "two words"},
+		{"C", `quoted "value" # not a comment`},
+		{"D", "single $quoted"},
+		{"E", ""},
+	}, entries)
+
+	_, err = parseDotEnv(strings.NewReader("A=\"unterminated\n"))
+	assert.EqualError(t, err, "invalid value on line 1: unterminated double quote")
+
+	_, err = parseDotEnv(strings.NewReader("not a variable\n"))
+	assert.EqualError(t, err, "invalid line 1: not a variable")
+}
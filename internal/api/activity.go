@@ -0,0 +1,176 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// ActivityState is the state of an activity.
+type ActivityState string
+
+// Activity states.
+const (
+	ActivityStatePending    ActivityState = "pending"
+	ActivityStateScheduled  ActivityState = "scheduled"
+	ActivityStateStaged     ActivityState = "staged"
+	ActivityStateInProgress ActivityState = "in_progress"
+	ActivityStateComplete   ActivityState = "complete"
+	ActivityStateCancelled  ActivityState = "cancelled"
+)
+
+// ActivityResult is the result of a completed activity.
+type ActivityResult string
+
+// Activity results.
+const (
+	ActivityResultSuccess ActivityResult = "success"
+	ActivityResultFailure ActivityResult = "failure"
+)
+
+// Activity is a project or environment activity.
+type Activity struct {
+	ID                string         `json:"id"`
+	Type              string         `json:"type"`
+	State             ActivityState  `json:"state"`
+	Result            ActivityResult `json:"result"`
+	CompletionPercent int            `json:"completion_percent"`
+	Project           string         `json:"project"`
+	Environments      []string       `json:"environments"`
+	Description       string         `json:"description"`
+	Text              string         `json:"text"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	StartedAt         *time.Time     `json:"started_at"`
+	CompletedAt       *time.Time     `json:"completed_at"`
+	Payload           map[string]any `json:"payload"`
+
+	HALResource
+}
+
+// activitiesResult is the response to a change which may start activities.
+type activitiesResult struct {
+	Embedded struct {
+		Activities []*Activity `json:"activities"`
+	} `json:"_embedded"`
+}
+
+// IsComplete returns whether the activity has finished (including if it was cancelled).
+func (a *Activity) IsComplete() bool {
+	return a.State == ActivityStateComplete || a.State == ActivityStateCancelled
+}
+
+// IsSuccess returns whether the activity completed successfully.
+func (a *Activity) IsSuccess() bool {
+	return a.State == ActivityStateComplete && a.Result == ActivityResultSuccess
+}
+
+// GetActivity gets a single project activity by ID.
+func (c *Client) GetActivity(ctx context.Context, projectID, id string) (*Activity, error) {
+	u, err := c.baseURLWithSegments("projects", projectID, "activities", id)
+	if err != nil {
+		return nil, err
+	}
+	return Get[Activity](ctx, c, u.String())
+}
+
+// WaitForActivity polls an activity, via its "self" link, until it is complete.
+func (c *Client) WaitForActivity(ctx context.Context, a *Activity, interval time.Duration) (*Activity, error) {
+	if a.IsComplete() {
+		return a, nil
+	}
+	selfURL, ok := a.GetLink("self")
+	if !ok {
+		return nil, ErrNoLink
+	}
+	for !a.IsComplete() {
+		select {
+		case <-ctx.Done():
+			return a, ctx.Err()
+		case <-time.After(interval):
+		}
+		updated, err := Get[Activity](ctx, c, selfURL)
+		if err != nil {
+			return a, err
+		}
+		a = updated
+	}
+	return a, nil
+}
+
+// ActivityLogEntry is an entry in an activity's log.
+type ActivityLogEntry struct {
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// activityLogItem is a line of the activity log stream.
+type activityLogItem struct {
+	Data *ActivityLogEntry `json:"data"`
+	Seal bool              `json:"seal"`
+}
+
+// StreamActivityLog follows an activity's log, via its "log" link, calling fn
+// for each entry. It returns when the log is sealed (i.e. complete), or when
+// the stream ends for another reason, reporting whether it was sealed.
+//
+// The log may be resumed after a given time, e.g. when reconnecting.
+func (c *Client) StreamActivityLog(
+	ctx context.Context,
+	a *Activity,
+	startAt time.Time,
+	fn func(ActivityLogEntry),
+) (sealed bool, err error) {
+	logURL, ok := a.GetLink("log")
+	if !ok {
+		return false, ErrNoLink
+	}
+	u, err := c.resolveURL(logURL)
+	if err != nil {
+		return false, Error{Original: err, URL: logURL}
+	}
+	if !startAt.IsZero() {
+		q := u.Query()
+		q.Set("start_at", startAt.Format(time.RFC3339Nano))
+		u.RawQuery = q.Encode()
+	}
+	urlStr := u.String()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, http.NoBody)
+	if err != nil {
+		return false, Error{Original: err, URL: urlStr}
+	}
+	req.Header.Set("Accept", "application/x-ndjson, application/json")
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return false, Error{Original: err, URL: urlStr, Response: resp}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, newResponseError(resp, urlStr)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var item activityLogItem
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			return false, Error{Original: err, URL: urlStr, Response: resp}
+		}
+		if item.Seal {
+			return true, nil
+		}
+		if item.Data != nil {
+			fn(*item.Data)
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return false, Error{Original: err, URL: urlStr, Response: resp}
+	}
+	return false, ctx.Err()
+}
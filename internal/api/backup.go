@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Backup is a backup of an environment.
+type Backup struct {
+	ID            string     `json:"id"`
+	EnvironmentID string     `json:"environment"`
+	Status        string     `json:"status"`
+	Safe          bool       `json:"safe"`
+	Restorable    bool       `json:"restorable"`
+	Automated     bool       `json:"automated"`
+	CommitID      string     `json:"commit_id"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	HALResource
+}
+
+// RestoreOptions are options for restoring a backup.
+type RestoreOptions struct {
+	// EnvironmentName is the environment to restore to (the backup's own environment by default).
+	EnvironmentName string `json:"environment_name,omitempty"`
+	// BranchFrom is the parent of the target environment, if it needs to be created.
+	BranchFrom  string `json:"branch_from,omitempty"`
+	RestoreCode bool   `json:"restore_code"`
+}
+
+func (c *Client) backupsURL(projectID, environmentID string, segments ...string) (*url.URL, error) {
+	return c.baseURLWithSegments(append(
+		[]string{"projects", projectID, "environments", environmentID, "backups"}, segments...)...)
+}
+
+// ListBackups lists the backups of an environment.
+func (c *Client) ListBackups(ctx context.Context, projectID, environmentID string) ([]*Backup, error) {
+	u, err := c.backupsURL(projectID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	return ListAll[*Backup](ctx, c, u.String())
+}
+
+// GetBackup gets a single backup by ID.
+func (c *Client) GetBackup(ctx context.Context, projectID, environmentID, id string) (*Backup, error) {
+	u, err := c.backupsURL(projectID, environmentID, id)
+	if err != nil {
+		return nil, err
+	}
+	return Get[Backup](ctx, c, u.String())
+}
+
+// GetBackupProperties gets a single backup by ID, as a map of its raw properties.
+func (c *Client) GetBackupProperties(ctx context.Context, projectID, environmentID, id string) (map[string]any, error) {
+	u, err := c.backupsURL(projectID, environmentID, id)
+	if err != nil {
+		return nil, err
+	}
+	props, err := Get[map[string]any](ctx, c, u.String())
+	if err != nil {
+		return nil, err
+	}
+	return *props, nil
+}
+
+// CreateBackup starts a backup of an environment, returning the activities
+// started. A "live" backup (safe=false) is taken without stopping the environment.
+func (c *Client) CreateBackup(ctx context.Context, projectID, environmentID string, safe bool) ([]*Activity, error) {
+	u, err := c.backupsURL(projectID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	var r activitiesResult
+	if err := c.request(ctx, http.MethodPost, u.String(), map[string]any{"safe": safe}, &r); err != nil {
+		return nil, err
+	}
+	return r.Embedded.Activities, nil
+}
+
+// RestoreBackup starts restoring a backup, returning the activities started.
+func (c *Client) RestoreBackup(
+	ctx context.Context,
+	projectID string,
+	b *Backup,
+	opts RestoreOptions,
+) ([]*Activity, error) {
+	u, err := c.backupsURL(projectID, b.EnvironmentID, b.ID, "restore")
+	if err != nil {
+		return nil, err
+	}
+	var r activitiesResult
+	if err := c.request(ctx, http.MethodPost, u.String(), opts, &r); err != nil {
+		return nil, err
+	}
+	return r.Embedded.Activities, nil
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/file"
+)
+
+// CacheMode controls how the CacheTransport uses cached responses.
+type CacheMode int
+
+const (
+	// CacheRevalidate revalidates cached responses with a conditional request.
+	CacheRevalidate CacheMode = iota
+	// CacheRefresh ignores cached responses, but stores new ones.
+	CacheRefresh
+	// CacheDisabled neither reads nor stores cached responses.
+	CacheDisabled
+)
+
+// maxCachedBodySize is the size of the largest response body that will be cached.
+const maxCachedBodySize = 5 << 20
+
+// CacheDir returns the directory for cached API responses of an auth session.
+func CacheDir(cnf *config.Config, sessionID string) (string, error) {
+	tmp, err := cnf.TempDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(tmp, "api-cache", sessionID), nil
+}
+
+// ClearCache deletes the cached API responses of an auth session.
+func ClearCache(cnf *config.Config, sessionID string) error {
+	dir, err := CacheDir(cnf, sessionID)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// CacheTransport is an http.RoundTripper which caches the responses of GET
+// requests on disk, and revalidates them with conditional requests, using the
+// ETag and Last-Modified headers.
+//
+// The cache directory should be specific to an auth session (see CacheDir),
+// so that responses cached for one user cannot be returned to another.
+type CacheTransport struct {
+	Base http.RoundTripper
+	Dir  string
+	Mode CacheMode
+
+	LogFunc func(msg string, args ...any)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t.Mode == CacheDisabled || t.Dir == "" || req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return base.RoundTrip(req)
+	}
+
+	path := t.path(req)
+	var cached *http.Response
+	if t.Mode == CacheRevalidate {
+		cached = t.load(path, req)
+	}
+	if cached != nil {
+		req = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		t.log("Using cached response for: %s", req.URL)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return cached, nil
+	}
+	if cached != nil {
+		_ = cached.Body.Close()
+	}
+	if t.cacheable(resp) {
+		return t.store(path, resp)
+	}
+	return resp, nil
+}
+
+// path returns the cache file path for a request, keyed by its URL and Accept header.
+func (t *CacheTransport) path(req *http.Request) string {
+	h := sha256.Sum256([]byte(req.URL.String() + "\n" + req.Header.Get("Accept")))
+	return filepath.Join(t.Dir, hex.EncodeToString(h[:]))
+}
+
+// cacheable checks whether a response can be stored.
+func (t *CacheTransport) cacheable(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	if resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+		return false
+	}
+	cc := strings.ToLower(resp.Header.Get("Cache-Control"))
+	return !strings.Contains(cc, "no-store") && resp.ContentLength <= maxCachedBodySize
+}
+
+// load reads a cached response, returning nil if there is none.
+func (t *CacheTransport) load(path string, req *http.Request) *http.Response {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.log("Failed to read cached response: %s", err)
+		}
+		return nil
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), req)
+	if err != nil {
+		t.log("Failed to parse cached response: %s", err)
+		_ = os.Remove(path)
+		return nil
+	}
+	return resp
+}
+
+// store saves a response to the cache, returning a response with an unread body.
+func (t *CacheTransport) store(path string, resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBodySize+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBodySize {
+		// Too large to cache: return the rest of the body unread.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// The response is dumped in wire format, which http.ReadResponse can read.
+	stored := *resp
+	stored.Body = io.NopCloser(bytes.NewReader(body))
+	stored.ContentLength = int64(len(body))
+	stored.TransferEncoding = nil
+	stored.Header = resp.Header.Clone()
+	stored.Header.Del("Set-Cookie")
+	dump, err := httputil.DumpResponse(&stored, true)
+	if err != nil {
+		t.log("Failed to encode response for the cache: %s", err)
+		return resp, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.log("Failed to create cache directory: %s", err)
+		return resp, nil
+	}
+	if err := file.Write(path, dump, 0o600); err != nil {
+		t.log("Failed to write cached response: %s", err)
+	}
+	return resp, nil
+}
+
+func (t *CacheTransport) log(msg string, args ...any) {
+	if t.LogFunc != nil {
+		t.LogFunc(msg, args...)
+	}
+}
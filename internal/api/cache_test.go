@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheTransport(t *testing.T) {
+	var (
+		requests    int
+		notModified int
+		etag        = `"v1"`
+		body        = `{"id": "a", "name": "first"}`
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	sessionDir := t.TempDir()
+	newClient := func(dir string, mode CacheMode) *Client {
+		c, err := NewClient(server.URL, &http.Client{Transport: &CacheTransport{Dir: dir, Mode: mode}})
+		require.NoError(t, err)
+		return c
+	}
+	get := func(c *Client) string {
+		item, err := Get[testItem](ctx, c, "/items/a")
+		require.NoError(t, err)
+		return item.Name
+	}
+
+	// The first request is stored, and the second revalidated.
+	assert.Equal(t, "first", get(newClient(sessionDir, CacheRevalidate)))
+	assert.Equal(t, 0, notModified)
+	assert.Equal(t, "first", get(newClient(sessionDir, CacheRevalidate)))
+	assert.Equal(t, 1, notModified)
+	assert.Equal(t, 2, requests)
+
+	// A changed resource is replaced in the cache.
+	etag, body = `"v2"`, `{"id": "a", "name": "second"}`
+	assert.Equal(t, "second", get(newClient(sessionDir, CacheRevalidate)))
+	assert.Equal(t, "second", get(newClient(sessionDir, CacheRevalidate)))
+	assert.Equal(t, 2, notModified)
+
+	// Refreshing skips the conditional request.
+	assert.Equal(t, "second", get(newClient(sessionDir, CacheRefresh)))
+	assert.Equal(t, 2, notModified)
+
+	// Disabling the cache skips it entirely.
+	assert.Equal(t, "second", get(newClient(sessionDir, CacheDisabled)))
+	assert.Equal(t, 2, notModified)
+
+	// Another session does not share the cache.
+	assert.Equal(t, "second", get(newClient(t.TempDir(), CacheRevalidate)))
+	assert.Equal(t, 2, notModified)
+	assert.Equal(t, 7, requests)
+}
@@ -3,10 +3,8 @@ package api
 import (
 	"fmt"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"path"
-	"strings"
 )
 
 // Client is an API client.
@@ -30,31 +28,6 @@ func NewClient(baseURL string, httpClient *http.Client) (*Client, error) {
 	}, nil
 }
 
-// Error is an error returned from an API call, allowing access to the response.
-type Error struct {
-	Original error
-	URL      string
-	Response *http.Response
-}
-
-func (e Error) Error() string {
-	var msg string
-	switch {
-	case e.Original != nil:
-		msg = fmt.Sprintf("API error: %s: %s", e.Original.Error(), e.URL)
-	case e.Response != nil:
-		msg = fmt.Sprintf("API error: %s: %s", e.Response.Status, e.URL)
-	default:
-		msg = fmt.Sprintf("API error: %s", e.URL)
-	}
-	if e.Response != nil && e.Response.StatusCode != http.StatusNotFound {
-		defer e.Response.Body.Close()
-		d, _ := httputil.DumpResponse(e.Response, false)
-		msg += "\n\nFull response:\n\n" + strings.TrimSpace(string(d)) + "\n"
-	}
-	return msg
-}
-
 // resolveURL adds path segments to the client's configured base URL, escaping each segment.
 func (c *Client) baseURLWithSegments(segments ...string) (*url.URL, error) {
 	var relativeURL string
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Environment statuses.
+const (
+	EnvironmentStatusActive   = "active"
+	EnvironmentStatusInactive = "inactive"
+	EnvironmentStatusPaused   = "paused"
+	EnvironmentStatusDirty    = "dirty"
+)
+
+// Environment is a project environment.
+type Environment struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	MachineName string    `json:"machine_name"`
+	Title       string    `json:"title"`
+	Type        string    `json:"type"`
+	Status      string    `json:"status"`
+	Parent      *string   `json:"parent"`
+	Project     string    `json:"project"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	HALResource
+}
+
+// ListEnvironments lists the environments of a project.
+func (c *Client) ListEnvironments(ctx context.Context, projectID string) ([]*Environment, error) {
+	u, err := c.baseURLWithSegments("projects", projectID, "environments")
+	if err != nil {
+		return nil, err
+	}
+	return ListAll[*Environment](ctx, c, u.String())
+}
+
+// GetEnvironment gets a single environment by ID.
+func (c *Client) GetEnvironment(ctx context.Context, projectID, id string) (*Environment, error) {
+	u, err := c.baseURLWithSegments("projects", projectID, "environments", id)
+	if err != nil {
+		return nil, err
+	}
+	return Get[Environment](ctx, c, u.String())
+}
+
+// GetEnvironmentProperties gets a single environment by ID, as a map of its raw properties.
+func (c *Client) GetEnvironmentProperties(ctx context.Context, projectID, id string) (map[string]any, error) {
+	u, err := c.baseURLWithSegments("projects", projectID, "environments", id)
+	if err != nil {
+		return nil, err
+	}
+	props, err := Get[map[string]any](ctx, c, u.String())
+	if err != nil {
+		return nil, err
+	}
+	return *props, nil
+}
+
+// RunEnvironmentOperation runs an operation such as "activate", "pause",
+// "resume" or "redeploy" on an environment, via its "#{operation}" link.
+// It returns the activities that the operation started.
+func (c *Client) RunEnvironmentOperation(ctx context.Context, env *Environment, op string) ([]*Activity, error) {
+	opURL, ok := env.GetLink("#" + op)
+	if !ok {
+		return nil, fmt.Errorf("operation not available: %s (environment: %s, status: %s)", op, env.ID, env.Status)
+	}
+	var r activitiesResult
+	if err := c.request(ctx, http.MethodPost, opURL, map[string]any{}, &r); err != nil {
+		return nil, err
+	}
+	return r.Embedded.Activities, nil
+}
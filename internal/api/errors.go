@@ -0,0 +1,260 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+// Error is an error returned from an API call, allowing access to the response.
+//
+// If the response contained an error body, it is decoded into the Code,
+// Message, Detail and Validation fields. Both the API's standard format and
+// "problem details" (RFC 9457) are supported.
+type Error struct {
+	Original error
+	URL      string
+	Response *http.Response
+
+	Code       int                 // The error code from the body, or the HTTP status code.
+	Message    string              // The error message or title.
+	Detail     string              // Further detail about the error.
+	Validation map[string][]string // Validation errors keyed by field.
+	RawBody    []byte              // The raw response body (which may be truncated).
+}
+
+// errorBody contains the fields of the API's error formats.
+type errorBody struct {
+	Code          int             `json:"code"`
+	Status        json.RawMessage `json:"status"`
+	Message       string          `json:"message"`
+	Title         string          `json:"title"`
+	Detail        json.RawMessage `json:"detail"`
+	Violations    []violation     `json:"violations"`
+	InvalidParams []violation     `json:"invalid_params"`
+}
+
+type violation struct {
+	PropertyPath string `json:"property_path"`
+	Field        string `json:"field"`
+	Name         string `json:"name"`
+	Message      string `json:"message"`
+	Reason       string `json:"reason"`
+}
+
+// newResponseError creates an Error from an unsuccessful response, decoding its body.
+func newResponseError(resp *http.Response, urlStr string) Error {
+	e := Error{Response: resp, URL: urlStr, Code: resp.StatusCode}
+	b, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil || len(b) == 0 {
+		return e
+	}
+	e.RawBody = b
+
+	var body errorBody
+	if err := json.Unmarshal(b, &body); err != nil {
+		return e
+	}
+	if body.Code == 0 {
+		// In problem details, "status" is the HTTP status code.
+		_ = json.Unmarshal(body.Status, &body.Code)
+	}
+	if body.Code != 0 {
+		e.Code = body.Code
+	}
+	e.Message = body.Message
+	if e.Message == "" {
+		e.Message = body.Title
+	}
+
+	// The detail is either a string, or an object of validation errors.
+	var detailMap map[string]any
+	if err := json.Unmarshal(body.Detail, &e.Detail); err != nil {
+		if err := json.Unmarshal(body.Detail, &detailMap); err == nil {
+			for field, v := range detailMap {
+				e.addValidation(field, v)
+			}
+		}
+	}
+	for _, v := range slices.Concat(body.Violations, body.InvalidParams) {
+		field := v.PropertyPath
+		if field == "" {
+			field = v.Field
+		}
+		if field == "" {
+			field = v.Name
+		}
+		msg := v.Message
+		if msg == "" {
+			msg = v.Reason
+		}
+		e.addValidation(field, msg)
+	}
+
+	return e
+}
+
+// addValidation adds validation messages for a field, from a string or a list of strings.
+func (e *Error) addValidation(field string, v any) {
+	if e.Validation == nil {
+		e.Validation = make(map[string][]string)
+	}
+	switch msg := v.(type) {
+	case string:
+		e.Validation[field] = append(e.Validation[field], msg)
+	case []any:
+		for _, m := range msg {
+			e.addValidation(field, m)
+		}
+	default:
+		if b, err := json.Marshal(msg); err == nil {
+			e.Validation[field] = append(e.Validation[field], string(b))
+		}
+	}
+}
+
+func (e Error) Error() string {
+	switch {
+	case e.Original != nil:
+		return fmt.Sprintf("API error: %s: %s", e.Original.Error(), e.URL)
+	case e.Response != nil:
+		msg := fmt.Sprintf("API error: %s", e.Response.Status)
+		if s := e.Summary(); s != "" {
+			msg += ": " + s
+		}
+		return msg + ": " + e.URL
+	default:
+		return fmt.Sprintf("API error: %s", e.URL)
+	}
+}
+
+func (e Error) Unwrap() error {
+	return e.Original
+}
+
+// StatusCode returns the HTTP status code, or 0 if there was no response.
+func (e Error) StatusCode() int {
+	if e.Response == nil {
+		return 0
+	}
+	return e.Response.StatusCode
+}
+
+// Summary returns the error message and detail, if any, on one line.
+func (e Error) Summary() string {
+	msg := e.Message
+	if e.Detail != "" && e.Detail != msg {
+		if msg != "" {
+			msg += " (" + e.Detail + ")"
+		} else {
+			msg = e.Detail
+		}
+	}
+	return msg
+}
+
+// ValidationMessages returns the validation errors as a sorted list of "field: message" lines.
+func (e Error) ValidationMessages() []string {
+	fields := make([]string, 0, len(e.Validation))
+	for f := range e.Validation {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	var lines []string
+	for _, f := range fields {
+		for _, msg := range e.Validation[f] {
+			if f == "" {
+				lines = append(lines, msg)
+			} else {
+				lines = append(lines, f+": "+msg)
+			}
+		}
+	}
+	return lines
+}
+
+// Dump returns the full request URL and response, for debugging.
+func (e Error) Dump() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "URL: %s\n", e.URL)
+	if e.Original != nil {
+		fmt.Fprintf(&b, "Error: %s\n", e.Original)
+	}
+	if e.Response != nil {
+		d, _ := httputil.DumpResponse(e.Response, false)
+		b.WriteString("\n")
+		b.Write(bytes.TrimSpace(d))
+		b.WriteString("\n")
+		if len(e.RawBody) > 0 {
+			b.WriteString("\n")
+			b.Write(e.RawBody)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// Retryable returns whether the request may succeed if it is retried later.
+func (e Error) Retryable() bool {
+	switch e.StatusCode() {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return e.Original != nil && isTemporaryNetError(e.Original)
+}
+
+// isTemporaryNetError checks whether a request failed due to a connection
+// problem, such as a reset or a timeout, that may not happen again.
+func isTemporaryNetError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+func hasStatus(err error, status int) bool {
+	var e Error
+	return errors.As(err, &e) && e.StatusCode() == status
+}
+
+// IsNotFound checks if an error is an API "404 Not Found" error.
+func IsNotFound(err error) bool { return hasStatus(err, http.StatusNotFound) }
+
+// IsForbidden checks if an error is an API "403 Forbidden" error.
+func IsForbidden(err error) bool { return hasStatus(err, http.StatusForbidden) }
+
+// IsUnauthorized checks if an error is an API "401 Unauthorized" error.
+func IsUnauthorized(err error) bool { return hasStatus(err, http.StatusUnauthorized) }
+
+// IsConflict checks if an error is an API "409 Conflict" error.
+func IsConflict(err error) bool { return hasStatus(err, http.StatusConflict) }
+
+// IsRateLimited checks if an error is an API "429 Too Many Requests" error.
+func IsRateLimited(err error) bool { return hasStatus(err, http.StatusTooManyRequests) }
+
+// IsValidation checks if an error is an API error caused by invalid input.
+func IsValidation(err error) bool {
+	var e Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.StatusCode() == http.StatusBadRequest || e.StatusCode() == http.StatusUnprocessableEntity ||
+		len(e.Validation) > 0
+}
+
+// IsRetryable checks if an error is an API error which may succeed if retried.
+func IsRetryable(err error) bool {
+	var e Error
+	return errors.As(err, &e) && e.Retryable()
+}
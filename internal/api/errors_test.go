@@ -0,0 +1,110 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+	}
+}
+
+func TestNewResponseError(t *testing.T) {
+	cases := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		wantDetail  string
+		wantCode    int
+		wantLines   []string
+		wantError   string
+	}{
+		{
+			name:        "standard",
+			status:      http.StatusNotFound,
+			body:        `{"status": "error", "code": 404, "message": "Not Found", "detail": "The project does not exist."}`,
+			wantMessage: "Not Found",
+			wantDetail:  "The project does not exist.",
+			wantCode:    404,
+			wantError:   "API error: 404 Not Found: Not Found (The project does not exist.): http://example.com/x",
+		},
+		{
+			name:   "standard with field errors",
+			status: http.StatusBadRequest,
+			body: `{"status": "error", "code": 400, "message": "Bad Request",
+				"detail": {"name": "required", "size": ["too big"]}}`,
+			wantMessage: "Bad Request",
+			wantCode:    400,
+			wantLines:   []string{"name: required", "size: too big"},
+		},
+		{
+			name:   "problem details",
+			status: http.StatusUnprocessableEntity,
+			body: `{"type": "about:blank", "title": "Validation failed", "status": 422,
+				"detail": "The request is invalid.", "violations": [{"property_path": "email", "message": "invalid"}]}`,
+			wantMessage: "Validation failed",
+			wantDetail:  "The request is invalid.",
+			wantCode:    422,
+			wantLines:   []string{"email: invalid"},
+		},
+		{
+			name:      "not JSON",
+			status:    http.StatusBadGateway,
+			body:      `<html>Bad Gateway</html>`,
+			wantCode:  502,
+			wantError: "API error: 502 Bad Gateway: http://example.com/x",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := newResponseError(testResponse(c.status, c.body), "http://example.com/x")
+			assert.Equal(t, c.wantMessage, err.Message)
+			assert.Equal(t, c.wantDetail, err.Detail)
+			assert.Equal(t, c.wantCode, err.Code)
+			assert.Equal(t, c.wantLines, err.ValidationMessages())
+			assert.Equal(t, c.body, string(err.RawBody))
+			assert.Contains(t, err.Dump(), c.body)
+			if c.wantError != "" {
+				assert.Equal(t, c.wantError, err.Error())
+			}
+		})
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	respErr := func(status int) error {
+		return fmt.Errorf("wrapped: %w", newResponseError(testResponse(status, ""), "http://example.com"))
+	}
+
+	assert.True(t, IsNotFound(respErr(http.StatusNotFound)))
+	assert.False(t, IsNotFound(respErr(http.StatusForbidden)))
+	assert.True(t, IsForbidden(respErr(http.StatusForbidden)))
+	assert.True(t, IsUnauthorized(respErr(http.StatusUnauthorized)))
+	assert.True(t, IsConflict(respErr(http.StatusConflict)))
+	assert.True(t, IsRateLimited(respErr(http.StatusTooManyRequests)))
+	assert.True(t, IsValidation(respErr(http.StatusUnprocessableEntity)))
+	assert.False(t, IsNotFound(fmt.Errorf("other")))
+
+	for _, status := range []int{408, 429, 502, 503, 504} {
+		assert.True(t, IsRetryable(respErr(status)), status)
+	}
+	for _, status := range []int{400, 401, 403, 404, 500} {
+		assert.False(t, IsRetryable(respErr(status)), status)
+	}
+	assert.True(t, IsRetryable(Error{Original: fmt.Errorf("read: %w", syscall.ECONNRESET)}))
+	assert.False(t, IsRetryable(Error{Original: fmt.Errorf("invalid")}))
+}
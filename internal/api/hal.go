@@ -0,0 +1,110 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// HALLink is a link to another resource, in Hypertext Application Language (HAL).
+type HALLink struct {
+	HREF      string `json:"href"`
+	Method    string `json:"method,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Templated bool   `json:"templated,omitempty"`
+}
+
+// HALLinks represents the HAL links on a resource, keyed by relation.
+// Most are an object containing a single "href", but some are arrays e.g. "curies".
+// Both forms are decoded into a list of links.
+type HALLinks map[string][]HALLink
+
+// UnmarshalJSON decodes links which may each be an object or an array of objects.
+func (l *HALLinks) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	links := make(HALLinks, len(raw))
+	for rel, v := range raw {
+		v = bytes.TrimSpace(v)
+		if len(v) > 0 && v[0] == '[' {
+			var list []HALLink
+			if err := json.Unmarshal(v, &list); err != nil {
+				return err
+			}
+			links[rel] = list
+			continue
+		}
+		var link HALLink
+		if err := json.Unmarshal(v, &link); err != nil {
+			return err
+		}
+		links[rel] = []HALLink{link}
+	}
+	*l = links
+	return nil
+}
+
+// MarshalJSON encodes single links as objects, and multiple links (or curies) as arrays.
+func (l HALLinks) MarshalJSON() ([]byte, error) {
+	rels := make([]string, 0, len(l))
+	for rel := range l {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, rel := range rels {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(rel)
+		if err != nil {
+			return nil, err
+		}
+		var v []byte
+		if len(l[rel]) == 1 && rel != "curies" {
+			v, err = json.Marshal(l[rel][0])
+		} else {
+			v, err = json.Marshal(l[rel])
+		}
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// Get returns the first link for a relation.
+func (l HALLinks) Get(rel string) (HALLink, bool) {
+	if len(l[rel]) == 0 {
+		return HALLink{}, false
+	}
+	return l[rel][0], true
+}
+
+// GetLink returns the URL of the first link for a relation.
+func (l HALLinks) GetLink(rel string) (string, bool) {
+	link, ok := l.Get(rel)
+	if !ok || link.HREF == "" {
+		return "", false
+	}
+	return link.HREF, true
+}
+
+// HALResource can be embedded in a model, so that it implements Resource.
+type HALResource struct {
+	Links HALLinks `json:"_links,omitempty"`
+}
+
+// GetLink returns the URL of a resource's link by relation.
+func (r *HALResource) GetLink(rel string) (string, bool) {
+	return r.Links.GetLink(rel)
+}
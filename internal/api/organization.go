@@ -2,6 +2,10 @@ package api
 
 import (
 	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -23,7 +27,8 @@ type Organization struct {
 	Status       string    `json:"status"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
-	Links        HALLinks  `json:"_links"`
+
+	HALResource
 }
 
 // GetOrganization gets a single organization by ID.
@@ -35,3 +40,165 @@ func (c *Client) GetOrganization(ctx context.Context, id string) (o *Organizatio
 	err = c.getResource(ctx, u.String(), &o)
 	return
 }
+
+// Organization member permissions.
+const (
+	OrgPermissionAdmin          = "admin"
+	OrgPermissionBilling        = "billing"
+	OrgPermissionMembers        = "members"
+	OrgPermissionPlans          = "plans"
+	OrgPermissionProjectsCreate = "projects:create"
+	OrgPermissionProjectsList   = "projects:list"
+)
+
+// OrgPermissions lists the permissions that can be granted to organization members.
+var OrgPermissions = []string{
+	OrgPermissionAdmin,
+	OrgPermissionBilling,
+	OrgPermissionMembers,
+	OrgPermissionPlans,
+	OrgPermissionProjectsCreate,
+	OrgPermissionProjectsList,
+}
+
+// IsFlexible returns whether the organization is of the "flexible" type (rather than "fixed").
+func (o *Organization) IsFlexible() bool {
+	return o.Type == OrgTypeFlexible
+}
+
+// HasCapability returns whether the organization has a capability.
+func (o *Organization) HasCapability(c string) bool {
+	return slices.Contains(o.Capabilities, c)
+}
+
+// GetOrganizationByName gets a single organization by its machine name.
+func (c *Client) GetOrganizationByName(ctx context.Context, name string) (*Organization, error) {
+	// The "=" must be escaped so the API does not treat "name=" as part of an ID.
+	u, err := c.resolveURL("organizations/name%3D" + url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+	return Get[Organization](ctx, c, u.String())
+}
+
+// ListUserOrganizations lists the organizations which a user is a member of.
+func (c *Client) ListUserOrganizations(ctx context.Context, userID string) ([]*Organization, error) {
+	u, err := c.baseURLWithSegments("users", userID, "organizations")
+	if err != nil {
+		return nil, err
+	}
+	return ListAll[*Organization](ctx, c, u.String())
+}
+
+// OrganizationMember is a user's membership of an organization.
+type OrganizationMember struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	UserID         string    `json:"user_id"`
+	Permissions    []string  `json:"permissions"`
+	Level          string    `json:"level"`
+	Owner          bool      `json:"owner"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	// User is the member's user account, resolved from references.
+	User *User `json:"-"`
+
+	HALResource
+}
+
+// ListOrganizationMembers lists the members of an organization, with their user accounts.
+func (c *Client) ListOrganizationMembers(ctx context.Context, orgID string) ([]*OrganizationMember, error) {
+	u, err := c.baseURLWithSegments("organizations", orgID, "members")
+	if err != nil {
+		return nil, err
+	}
+	var (
+		members  []*OrganizationMember
+		refLinks []string
+	)
+	for urlStr := u.String(); urlStr != ""; {
+		var page collectionPage[*OrganizationMember]
+		if err := c.getResource(ctx, urlStr, &page); err != nil {
+			return nil, err
+		}
+		members = append(members, page.Items...)
+		for rel := range page.Links {
+			if strings.HasPrefix(rel, "ref:users:") {
+				href, _ := page.Links.GetLink(rel)
+				refLinks = append(refLinks, href)
+			}
+		}
+		urlStr, _ = page.Links.GetLink("next")
+	}
+	users, err := getRefs[User](ctx, c, refLinks)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		m.User = users[m.UserID]
+	}
+	return members, nil
+}
+
+// UpdateOrganizationMember sets the permissions of an organization member.
+func (c *Client) UpdateOrganizationMember(
+	ctx context.Context,
+	orgID, userID string,
+	permissions []string,
+) (*OrganizationMember, error) {
+	u, err := c.baseURLWithSegments("organizations", orgID, "members", userID)
+	if err != nil {
+		return nil, err
+	}
+	var m OrganizationMember
+	if err := c.request(ctx, http.MethodPatch, u.String(), map[string]any{"permissions": permissions}, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// RemoveOrganizationMember removes a user from an organization.
+func (c *Client) RemoveOrganizationMember(ctx context.Context, orgID, userID string) error {
+	u, err := c.baseURLWithSegments("organizations", orgID, "members", userID)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, http.MethodDelete, u.String(), nil, nil)
+}
+
+// OrganizationInvitation is an invitation for a user to join an organization.
+type OrganizationInvitation struct {
+	ID          string    `json:"id"`
+	State       string    `json:"state"`
+	Email       string    `json:"email"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// InviteOrganizationMember invites a user, by email address, to join an organization.
+func (c *Client) InviteOrganizationMember(
+	ctx context.Context,
+	orgID, email string,
+	permissions []string,
+) (*OrganizationInvitation, error) {
+	u, err := c.baseURLWithSegments("organizations", orgID, "invitations")
+	if err != nil {
+		return nil, err
+	}
+	return Create[OrganizationInvitation](ctx, c, u.String(),
+		map[string]any{"email": email, "permissions": permissions})
+}
+
+// GetOrganizationProperties gets a single organization by ID, as a map of its raw properties.
+func (c *Client) GetOrganizationProperties(ctx context.Context, id string) (map[string]any, error) {
+	u, err := c.baseURLWithSegments("organizations", id)
+	if err != nil {
+		return nil, err
+	}
+	props, err := Get[map[string]any](ctx, c, u.String())
+	if err != nil {
+		return nil, err
+	}
+	return *props, nil
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Project is a project, as returned from the /projects/{id} endpoint.
+type Project struct {
+	ID            string    `json:"id"`
+	Title         string    `json:"title"`
+	Region        string    `json:"region"`
+	Organization  string    `json:"organization"`
+	Vendor        string    `json:"vendor"`
+	DefaultBranch string    `json:"default_branch"`
+	Status        string    `json:"status,omitempty"`
+	Timezone      string    `json:"timezone,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	Repository struct {
+		URL string `json:"url"`
+	} `json:"repository"`
+
+	HALResource
+}
+
+// ProjectRef is a project reference, as returned from the /ref/projects endpoint.
+type ProjectRef struct {
+	ID             string    `json:"id"`
+	Region         string    `json:"region"`
+	Title          string    `json:"title"`
+	Status         string    `json:"status"`
+	OrganizationID string    `json:"organization_id"`
+	SubscriptionID string    `json:"subscription_id"`
+	Vendor         string    `json:"vendor"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// OrganizationRef is an organization reference, as returned from the /ref/organizations endpoint.
+type OrganizationRef struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Label   string `json:"label"`
+	OwnerID string `json:"owner_id"`
+}
+
+// User is a user account.
+type User struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserProject is a project which a user can access, with its organization.
+type UserProject struct {
+	*ProjectRef
+	Organization *OrganizationRef
+}
+
+// GetProject gets a single project by ID.
+func (c *Client) GetProject(ctx context.Context, id string) (*Project, error) {
+	u, err := c.baseURLWithSegments("projects", id)
+	if err != nil {
+		return nil, err
+	}
+	return Get[Project](ctx, c, u.String())
+}
+
+// GetProjectProperties gets a single project by ID, as a map of its raw properties.
+func (c *Client) GetProjectProperties(ctx context.Context, id string) (map[string]any, error) {
+	u, err := c.baseURLWithSegments("projects", id)
+	if err != nil {
+		return nil, err
+	}
+	props, err := Get[map[string]any](ctx, c, u.String())
+	if err != nil {
+		return nil, err
+	}
+	return *props, nil
+}
+
+// GetMyUser gets the current user.
+func (c *Client) GetMyUser(ctx context.Context) (*User, error) {
+	return Get[User](ctx, c, "/users/me")
+}
+
+// VerificationStatus describes whether the current user must verify their
+// account (e.g. by phone or credit card) before creating a project.
+type VerificationStatus struct {
+	Required bool   `json:"state"`
+	Type     string `json:"type"`
+}
+
+// GetVerificationStatus checks whether the current user needs to verify their account.
+func (c *Client) GetVerificationStatus(ctx context.Context) (*VerificationStatus, error) {
+	var s VerificationStatus
+	if err := c.request(ctx, http.MethodPost, "/me/verification", nil, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// userGrant is a user's access to a resource.
+type userGrant struct {
+	ResourceID     string    `json:"resource_id"`
+	ResourceType   string    `json:"resource_type"`
+	OrganizationID string    `json:"organization_id"`
+	Permissions    []string  `json:"permissions"`
+	GrantedAt      time.Time `json:"granted_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// listUserProjectGrants lists a user's project grants, along with the
+// project and organization references linked from each page, keyed by type.
+func (c *Client) listUserProjectGrants(ctx context.Context, userID string) (
+	[]userGrant, map[string][]string, error) {
+	u, err := c.baseURLWithSegments("users", userID, "extended-access")
+	if err != nil {
+		return nil, nil, err
+	}
+	u.RawQuery = url.Values{"filter[resource_type]": {"project"}}.Encode()
+
+	var (
+		grants   []userGrant
+		refLinks = map[string][]string{}
+	)
+	for urlStr := u.String(); urlStr != ""; {
+		var page collectionPage[userGrant]
+		if err := c.getResource(ctx, urlStr, &page); err != nil {
+			return nil, nil, err
+		}
+		grants = append(grants, page.Items...)
+		for rel := range page.Links {
+			for _, refType := range []string{"projects", "organizations"} {
+				if strings.HasPrefix(rel, "ref:"+refType+":") {
+					href, _ := page.Links.GetLink(rel)
+					refLinks[refType] = append(refLinks[refType], href)
+				}
+			}
+		}
+		urlStr, _ = page.Links.GetLink("next")
+	}
+	return grants, refLinks, nil
+}
+
+// ListUserProjects lists the projects which a user can access.
+func (c *Client) ListUserProjects(ctx context.Context, userID string) ([]*UserProject, error) {
+	grants, refLinks, err := c.listUserProjectGrants(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	projectRefs, err := getRefs[ProjectRef](ctx, c, refLinks["projects"])
+	if err != nil {
+		return nil, err
+	}
+	orgRefs, err := getRefs[OrganizationRef](ctx, c, refLinks["organizations"])
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]*UserProject, 0, len(grants))
+	seen := make(map[string]struct{}, len(grants))
+	for _, g := range grants {
+		if _, ok := seen[g.ResourceID]; ok {
+			continue
+		}
+		seen[g.ResourceID] = struct{}{}
+		ref := projectRefs[g.ResourceID]
+		if ref == nil {
+			// The project may have been deleted.
+			continue
+		}
+		orgID := ref.OrganizationID
+		if orgID == "" {
+			orgID = g.OrganizationID
+		}
+		projects = append(projects, &UserProject{ProjectRef: ref, Organization: orgRefs[orgID]})
+	}
+	return projects, nil
+}
+
+// getRefs fetches references from a list of /ref/* URLs, keyed by ID.
+func getRefs[T any](ctx context.Context, c *Client, urls []string) (map[string]*T, error) {
+	refs := make(map[string]*T)
+	for _, urlStr := range urls {
+		var page map[string]*T
+		if err := c.getResource(ctx, urlStr, &page); err != nil {
+			return nil, fmt.Errorf("could not fetch references: %w", err)
+		}
+		for id, ref := range page {
+			if ref != nil {
+				refs[id] = ref
+			}
+		}
+	}
+	return refs, nil
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+)
+
+// Region is a region where projects can be hosted.
+type Region struct {
+	ID             string `json:"id"`
+	Label          string `json:"label"`
+	SelectionLabel string `json:"selection_label"`
+	Zone           string `json:"zone"`
+	Timezone       string `json:"timezone"`
+	Available      bool   `json:"available"`
+	Private        bool   `json:"private"`
+
+	Provider struct {
+		Name string `json:"name"`
+	} `json:"provider"`
+
+	Datacenter struct {
+		Name     string `json:"name"`
+		Label    string `json:"label"`
+		Location string `json:"location"`
+	} `json:"datacenter"`
+
+	// EnvironmentalImpact describes the carbon intensity of the region's
+	// electricity grid, if it is known.
+	EnvironmentalImpact *struct {
+		Zone            string `json:"zone"`
+		CarbonIntensity int    `json:"carbon_intensity"` // In gCO2eq/kWh.
+		Green           bool   `json:"green"`
+	} `json:"environmental_impact,omitempty"`
+}
+
+// ListRegions lists all regions.
+func (c *Client) ListRegions(ctx context.Context) ([]*Region, error) {
+	u, err := c.baseURLWithSegments("regions")
+	if err != nil {
+		return nil, err
+	}
+	r, err := Get[struct {
+		Regions []*Region `json:"regions"`
+	}](ctx, c, u.String())
+	if err != nil {
+		return nil, err
+	}
+	return r.Regions, nil
+}
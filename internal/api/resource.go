@@ -1,8 +1,12 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"iter"
 	"net/http"
 )
 
@@ -11,26 +15,146 @@ type Resource interface {
 	GetLink(name string) (string, bool)
 }
 
-// HALLinks represents the HAL links on a resource.
-// Most are an object containing a single "href", but some are arrays e.g. "curies".
-type HALLinks map[string]any
+// ErrNoLink is returned when a resource does not have a link needed for an
+// operation, which usually means the operation is not permitted.
+var ErrNoLink = errors.New("the resource does not have the required link")
 
 // getResource fetches an API resource from a URL and decodes it into an interface.
 func (c *Client) getResource(ctx context.Context, urlStr string, r any) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, http.NoBody)
+	return c.request(ctx, http.MethodGet, urlStr, nil, r)
+}
+
+// request makes an API request, encoding the body (if not nil) as JSON, and
+// decoding the response into r (if not nil).
+func (c *Client) request(ctx context.Context, method, urlStr string, body, r any) error {
+	resolved, err := c.resolveURL(urlStr)
 	if err != nil {
 		return Error{Original: err, URL: urlStr}
 	}
+	urlStr = resolved.String()
+
+	var reqBody io.Reader = http.NoBody
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return Error{Original: err, URL: urlStr}
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, reqBody)
+	if err != nil {
+		return Error{Original: err, URL: urlStr}
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return Error{Original: err, URL: urlStr, Response: resp}
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != 200 {
-		return Error{Response: resp, URL: urlStr}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newResponseError(resp, urlStr)
+	}
+	if r == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(r); err != nil {
 		return Error{Original: err, Response: resp, URL: urlStr}
 	}
 	return nil
 }
+
+// collectionPage is a page of a paginated collection.
+type collectionPage[T any] struct {
+	Items []T      `json:"items"`
+	Links HALLinks `json:"_links"`
+}
+
+// decodePage decodes a collection page, which may also be a plain array without pagination.
+func decodePage[T any](data json.RawMessage) (*collectionPage[T], error) {
+	page := &collectionPage[T]{}
+	if b := bytes.TrimSpace(data); len(b) > 0 && b[0] == '[' {
+		return page, json.Unmarshal(b, &page.Items)
+	}
+	return page, json.Unmarshal(data, page)
+}
+
+// List iterates over the items of a collection, following "next" links to
+// fetch further pages as needed. Iteration stops at the first error.
+func List[T any](ctx context.Context, c *Client, urlStr string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for urlStr != "" {
+			var data json.RawMessage
+			if err := c.getResource(ctx, urlStr, &data); err != nil {
+				yield(zero, err)
+				return
+			}
+			page, err := decodePage[T](data)
+			if err != nil {
+				yield(zero, Error{Original: err, URL: urlStr})
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			urlStr, _ = page.Links.GetLink("next")
+		}
+	}
+}
+
+// ListAll collects all the items of a collection, following pagination.
+func ListAll[T any](ctx context.Context, c *Client, urlStr string) ([]T, error) {
+	var items []T
+	for item, err := range List[T](ctx, c, urlStr) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Get fetches a single resource.
+func Get[T any](ctx context.Context, c *Client, urlStr string) (*T, error) {
+	var r T
+	if err := c.getResource(ctx, urlStr, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Create posts a new resource to a collection URL, and decodes the response.
+func Create[T any](ctx context.Context, c *Client, collectionURL string, body any) (*T, error) {
+	var r T
+	if err := c.request(ctx, http.MethodPost, collectionURL, body, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Patch updates a resource via its "#edit" link, and decodes the response.
+func Patch[T any](ctx context.Context, c *Client, res Resource, body any) (*T, error) {
+	editURL, ok := res.GetLink("#edit")
+	if !ok {
+		return nil, ErrNoLink
+	}
+	var r T
+	if err := c.request(ctx, http.MethodPatch, editURL, body, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Delete deletes a resource via its "#delete" link.
+func Delete(ctx context.Context, c *Client, res Resource) error {
+	deleteURL, ok := res.GetLink("#delete")
+	if !ok {
+		return ErrNoLink
+	}
+	return c.request(ctx, http.MethodDelete, deleteURL, nil, nil)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+
+	HALResource
+}
+
+func TestHALLinks(t *testing.T) {
+	var r testItem
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "a",
+		"_links": {
+			"self": {"href": "/items/a"},
+			"#edit": {"href": "/items/a", "method": "PATCH"},
+			"curies": [{"name": "ex", "href": "https://example.com/{rel}", "templated": true}]
+		}
+	}`), &r))
+
+	self, ok := r.GetLink("self")
+	assert.True(t, ok)
+	assert.Equal(t, "/items/a", self)
+
+	edit, ok := r.Links.Get("#edit")
+	assert.True(t, ok)
+	assert.Equal(t, "PATCH", edit.Method)
+
+	require.Len(t, r.Links["curies"], 1)
+	assert.Equal(t, HALLink{Name: "ex", HREF: "https://example.com/{rel}", Templated: true}, r.Links["curies"][0])
+
+	_, ok = r.GetLink("#delete")
+	assert.False(t, ok)
+
+	b, err := json.Marshal(r.Links)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"self": {"href": "/items/a"},
+		"#edit": {"href": "/items/a", "method": "PATCH"},
+		"curies": [{"name": "ex", "href": "https://example.com/{rel}", "templated": true}]
+	}`, string(b))
+}
+
+func TestResources(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items", func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("page") {
+		case "":
+			_, _ = w.Write([]byte(`{"items": [{"id": "a"}, {"id": "b"}], "_links": {"next": {"href": "/items?page=2"}}}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"items": [{"id": "c"}], "_links": {"self": {"href": "/items?page=2"}}}`))
+		}
+	})
+	mux.HandleFunc("GET /plain-items", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": "x"}]`))
+	})
+	mux.HandleFunc("POST /items", func(w http.ResponseWriter, req *http.Request) {
+		var item testItem
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&item))
+		if item.Name == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status": "error", "code": 400, "message": "Bad Request", "detail": "name is required"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "d", "name": "` + item.Name + `", "_links": {
+			"#edit": {"href": "/items/d"}, "#delete": {"href": "/items/d"}}}`))
+	})
+	mux.HandleFunc("PATCH /items/d", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "d", "name": "patched"}`))
+	})
+	mux.HandleFunc("DELETE /items/d", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(server.URL, http.DefaultClient)
+	require.NoError(t, err)
+
+	items, err := ListAll[testItem](ctx, client, "/items")
+	require.NoError(t, err)
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+
+	// Stopping iteration early does not fetch further pages.
+	for item, err := range List[testItem](ctx, client, "/items") {
+		require.NoError(t, err)
+		assert.Equal(t, "a", item.ID)
+		break
+	}
+
+	plain, err := ListAll[testItem](ctx, client, "/plain-items")
+	require.NoError(t, err)
+	require.Len(t, plain, 1)
+	assert.Equal(t, "x", plain[0].ID)
+
+	_, err = Create[testItem](ctx, client, "/items", testItem{})
+	var apiErr Error
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "Bad Request", apiErr.Message)
+	assert.Contains(t, err.Error(), "Bad Request (name is required)")
+
+	created, err := Create[testItem](ctx, client, "/items", testItem{Name: "new"})
+	require.NoError(t, err)
+	assert.Equal(t, "new", created.Name)
+
+	patched, err := Patch[testItem](ctx, client, created, map[string]any{"name": "patched"})
+	require.NoError(t, err)
+	assert.Equal(t, "patched", patched.Name)
+
+	require.NoError(t, Delete(ctx, client, created))
+	assert.ErrorIs(t, Delete(ctx, client, patched), ErrNoLink)
+}
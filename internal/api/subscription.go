@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Subscription statuses.
+const (
+	SubscriptionStatusProvisioning = "provisioning"
+	SubscriptionStatusActive       = "active"
+	SubscriptionStatusFailed       = "provisioning failure"
+)
+
+// Subscription is an organization's subscription for a project, which determines its plan and billing.
+type Subscription struct {
+	ID             string `json:"id"`
+	OrganizationID string `json:"organization_id"`
+	Plan           string `json:"plan"`
+	Status         string `json:"status"`
+	ProjectID      string `json:"project_id"`
+	ProjectTitle   string `json:"project_title"`
+	ProjectRegion  string `json:"project_region"`
+	ProjectUI      string `json:"project_ui"`
+
+	HALResource
+}
+
+// ListOrganizationSubscriptions lists an organization's subscriptions.
+func (c *Client) ListOrganizationSubscriptions(ctx context.Context, orgID string) ([]*Subscription, error) {
+	u, err := c.baseURLWithSegments("organizations", orgID, "subscriptions")
+	if err != nil {
+		return nil, err
+	}
+	return ListAll[*Subscription](ctx, c, u.String())
+}
+
+// CanCreateRequiredAction is an action a user must take before they can create a project.
+type CanCreateRequiredAction struct {
+	Action string `json:"action"`
+	Type   string `json:"type"`
+}
+
+// CanCreateResult describes whether a project can be created in an organization.
+type CanCreateResult struct {
+	CanCreate      bool                     `json:"can_create"`
+	Message        string                   `json:"message"`
+	RequiredAction *CanCreateRequiredAction `json:"required_action"`
+}
+
+// CanCreateSubscription checks whether a new project (subscription) can be created in an organization.
+func (c *Client) CanCreateSubscription(ctx context.Context, orgID string) (*CanCreateResult, error) {
+	u, err := c.baseURLWithSegments("organizations", orgID, "subscriptions", "can-create")
+	if err != nil {
+		return nil, err
+	}
+	return Get[CanCreateResult](ctx, c, u.String())
+}
+
+// SetupOptions are the choices available when creating a project in an organization.
+type SetupOptions struct {
+	Plans   []string `json:"plans"`
+	Regions []string `json:"regions"`
+}
+
+// GetSetupOptions gets the plans and regions available for a new project in an organization.
+func (c *Client) GetSetupOptions(ctx context.Context, orgID string) (*SetupOptions, error) {
+	u, err := c.baseURLWithSegments("organizations", orgID, "setup", "options")
+	if err != nil {
+		return nil, err
+	}
+	return Get[SetupOptions](ctx, c, u.String())
+}
+
+// SubscriptionOptions are the options for a new subscription (and its project).
+type SubscriptionOptions struct {
+	Title         string `json:"project_title"`
+	Region        string `json:"project_region"`
+	Plan          string `json:"plan,omitempty"`
+	DefaultBranch string `json:"default_branch,omitempty"`
+	Environments  int    `json:"environments,omitempty"`
+	Storage       int    `json:"storage,omitempty"`
+}
+
+// Estimate is an estimate of the cost of a subscription.
+type Estimate struct {
+	Total string `json:"total"`
+}
+
+// EstimateSubscription estimates the cost of a new subscription.
+func (c *Client) EstimateSubscription(ctx context.Context, orgID string, opts SubscriptionOptions) (*Estimate, error) {
+	u, err := c.baseURLWithSegments("organizations", orgID, "subscriptions", "estimate")
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{"plan": {opts.Plan}, "user_licenses": {"1"}}
+	if opts.Environments > 0 {
+		q.Set("environments", fmt.Sprint(opts.Environments))
+	}
+	if opts.Storage > 0 {
+		q.Set("storage", fmt.Sprint(opts.Storage*1024))
+	}
+	u.RawQuery = q.Encode()
+	return Get[Estimate](ctx, c, u.String())
+}
+
+// CreateSubscription creates a subscription, which starts provisioning a new project.
+func (c *Client) CreateSubscription(
+	ctx context.Context,
+	orgID string,
+	opts SubscriptionOptions,
+) (*Subscription, error) {
+	u, err := c.baseURLWithSegments("organizations", orgID, "subscriptions")
+	if err != nil {
+		return nil, err
+	}
+	return Create[Subscription](ctx, c, u.String(), opts)
+}
+
+// GetSubscription gets an organization's subscription by ID.
+func (c *Client) GetSubscription(ctx context.Context, orgID, id string) (*Subscription, error) {
+	u, err := c.baseURLWithSegments("organizations", orgID, "subscriptions", id)
+	if err != nil {
+		return nil, err
+	}
+	return Get[Subscription](ctx, c, u.String())
+}
+
+// ErrProvisioningFailed is returned when a subscription's project could not be provisioned.
+var ErrProvisioningFailed = errors.New("the project could not be provisioned")
+
+// WaitForSubscription polls a subscription until its project is active, or until the context is done.
+func (c *Client) WaitForSubscription(
+	ctx context.Context,
+	sub *Subscription,
+	interval time.Duration,
+) (*Subscription, error) {
+	for {
+		switch sub.Status {
+		case SubscriptionStatusActive:
+			if sub.ProjectID != "" {
+				return sub, nil
+			}
+		case SubscriptionStatusFailed:
+			return sub, ErrProvisioningFailed
+		}
+		select {
+		case <-ctx.Done():
+			return sub, ctx.Err()
+		case <-time.After(interval):
+		}
+		updated, err := c.GetSubscription(ctx, sub.OrganizationID, sub.ID)
+		if err != nil {
+			return sub, err
+		}
+		sub = updated
+	}
+}
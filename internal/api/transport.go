@@ -0,0 +1,296 @@
+package api
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the default number of times a request is retried.
+	DefaultMaxRetries = 3
+	// DefaultRateLimit is the default number of requests per second allowed to each host.
+	DefaultRateLimit = 10
+	// DefaultRateBurst is the default number of requests which can be made to a host at once.
+	DefaultRateBurst = 20
+
+	defaultMinBackoff    = 500 * time.Millisecond
+	defaultMaxBackoff    = 30 * time.Second
+	defaultMaxRetryAfter = 2 * time.Minute
+)
+
+// Clock provides the time, and sleeps, so that tests can replace it.
+type Clock interface {
+	Now() time.Time
+	// Sleep waits for the duration, or returns an error if the context is canceled first.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// NewTransport returns the RoundTripper chain used for API requests: requests
+// are retried by a RetryTransport, and each attempt is rate limited by a
+// RateLimitTransport, before being passed to base.
+//
+// If base is nil then http.DefaultTransport is used. If clock is nil then the
+// real time is used.
+func NewTransport(base http.RoundTripper, clock Clock) *RetryTransport {
+	return &RetryTransport{
+		Base: &RateLimitTransport{
+			Base:  base,
+			Clock: clock,
+		},
+		Clock: clock,
+	}
+}
+
+// RetryTransport is an http.RoundTripper which retries failed requests.
+//
+// Requests are retried if the response is "429 Too Many Requests" or, for
+// idempotent requests only, if the response is a 502, 503 or 504 error or the
+// connection failed. The delay between attempts grows exponentially, with
+// jitter, unless the response has a Retry-After header.
+type RetryTransport struct {
+	Base  http.RoundTripper
+	Clock Clock
+
+	// MaxRetries is the number of times to retry a request (default: DefaultMaxRetries).
+	MaxRetries int
+	// MinBackoff is the delay before the first retry, which doubles after each attempt.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay between attempts.
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest Retry-After delay to wait for; longer delays are not retried.
+	MaxRetryAfter time.Duration
+
+	LogFunc func(msg string, args ...any)
+
+	// jitter returns a random duration in [0, d). It is replaced in tests.
+	jitter func(d time.Duration) time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxRetries := t.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body cannot be rewound, so the request cannot be retried.
+		maxRetries = 0
+	}
+
+	var (
+		attemptReq = req
+		resp       *http.Response
+		err        error
+	)
+	for attempt := 0; ; attempt++ {
+		resp, err = t.base().RoundTrip(attemptReq)
+		if attempt >= maxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+		delay, ok := t.delay(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			t.log("Retrying request after %s (status %d): %s", delay, resp.StatusCode, req.URL)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		} else {
+			t.log("Retrying request after %s (%s): %s", delay, err, req.URL)
+		}
+		if err := t.clock().Sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			if attemptReq.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// shouldRetry checks whether an attempt should be retried.
+func (t *RetryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return isIdempotent(req) && req.Context().Err() == nil && isTemporaryNetError(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// The request was not processed, so it can be retried regardless of its method.
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt, and false if the
+// server asks for a delay longer than the maximum.
+func (t *RetryTransport) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), t.clock().Now()); ok {
+			maxRetryAfter := t.MaxRetryAfter
+			if maxRetryAfter <= 0 {
+				maxRetryAfter = defaultMaxRetryAfter
+			}
+			return d, d <= maxRetryAfter
+		}
+	}
+
+	minBackoff, maxBackoff := t.MinBackoff, t.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultMinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	backoff := minBackoff << attempt
+	if backoff > maxBackoff || backoff <= 0 {
+		backoff = maxBackoff
+	}
+
+	// Use "equal jitter": wait for at least half of the backoff.
+	jitter := t.jitter
+	if jitter == nil {
+		jitter = func(d time.Duration) time.Duration { return rand.N(d) } //nolint:gosec // Not for security.
+	}
+	return backoff/2 + jitter(backoff/2), true
+}
+
+func (t *RetryTransport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+func (t *RetryTransport) clock() Clock {
+	if t.Clock == nil {
+		return realClock{}
+	}
+	return t.Clock
+}
+
+func (t *RetryTransport) log(msg string, args ...any) {
+	if t.LogFunc != nil {
+		t.LogFunc(msg, args...)
+	}
+}
+
+// isIdempotent checks whether a request can safely be repeated.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(secs)*time.Second, 0), true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// RateLimitTransport is an http.RoundTripper which limits the rate of requests
+// to each host, using a token bucket. Requests wait until they are allowed.
+type RateLimitTransport struct {
+	Base  http.RoundTripper
+	Clock Clock
+
+	// Rate is the number of requests per second allowed to each host (default: DefaultRateLimit).
+	Rate float64
+	// Burst is the number of requests which can be made at once (default: DefaultRateBurst).
+	Burst int
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	clock := t.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+	if wait := t.reserve(req.URL.Host, clock.Now()); wait > 0 {
+		if err := clock.Sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+	if t.Base == nil {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+	return t.Base.RoundTrip(req)
+}
+
+// reserve takes a token from the host's bucket, and returns how long to wait
+// until the token is available.
+func (t *RateLimitTransport) reserve(host string, now time.Time) time.Duration {
+	rate := t.Rate
+	if rate <= 0 {
+		rate = DefaultRateLimit
+	}
+	burst := float64(t.Burst)
+	if burst <= 0 {
+		burst = DefaultRateBurst
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.buckets == nil {
+		t.buckets = make(map[string]*tokenBucket)
+	}
+	b, ok := t.buckets[host]
+	if !ok {
+		b = &tokenBucket{tokens: burst, last: now}
+		t.buckets[host] = b
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(burst, b.tokens+elapsed.Seconds()*rate)
+		b.last = now
+	}
+
+	// The bucket may go into debt: later callers wait for longer.
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / rate * float64(time.Second))
+}
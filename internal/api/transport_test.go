@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock records sleeps and advances its time instantly.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(_ context.Context, d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return nil
+}
+
+// scriptedTransport returns the next of a list of responses or errors.
+type scriptedTransport struct {
+	steps  []func(req *http.Request) (*http.Response, error)
+	bodies []string
+}
+
+func (s *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		s.bodies = append(s.bodies, string(b))
+	}
+	step := s.steps[0]
+	if len(s.steps) > 1 {
+		s.steps = s.steps[1:]
+	}
+	return step(req)
+}
+
+func respond(status int, header ...string) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		for i := 0; i+1 < len(header); i += 2 {
+			rec.Header().Set(header[i], header[i+1])
+		}
+		rec.WriteHeader(status)
+		resp := rec.Result()
+		resp.Request = req
+		return resp, nil
+	}
+}
+
+func fail(err error) func(req *http.Request) (*http.Response, error) {
+	return func(*http.Request) (*http.Response, error) { return nil, err }
+}
+
+func TestRetryTransport(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		method     string
+		steps      []func(req *http.Request) (*http.Response, error)
+		wantStatus int
+		wantErr    bool
+		wantSleeps []time.Duration
+	}{
+		{
+			name:       "backoff",
+			method:     http.MethodGet,
+			steps:      []func(*http.Request) (*http.Response, error){respond(502), respond(503), respond(504), respond(200)},
+			wantStatus: 200,
+			wantSleeps: []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, time.Second},
+		},
+		{
+			name:       "gives up",
+			method:     http.MethodGet,
+			steps:      []func(*http.Request) (*http.Response, error){respond(503)},
+			wantStatus: 503,
+			wantSleeps: []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, time.Second},
+		},
+		{
+			name:   "connection reset",
+			method: http.MethodGet,
+			steps: []func(*http.Request) (*http.Response, error){
+				fail(syscall.ECONNRESET), respond(200),
+			},
+			wantStatus: 200,
+			wantSleeps: []time.Duration{250 * time.Millisecond},
+		},
+		{
+			name:       "not idempotent",
+			method:     http.MethodPost,
+			steps:      []func(*http.Request) (*http.Response, error){respond(503), respond(200)},
+			wantStatus: 503,
+		},
+		{
+			name:    "not idempotent connection reset",
+			method:  http.MethodPost,
+			steps:   []func(*http.Request) (*http.Response, error){fail(syscall.ECONNRESET)},
+			wantErr: true,
+		},
+		{
+			name:   "retry after seconds",
+			method: http.MethodPost,
+			steps: []func(*http.Request) (*http.Response, error){
+				respond(429, "Retry-After", "7"), respond(200),
+			},
+			wantStatus: 200,
+			wantSleeps: []time.Duration{7 * time.Second},
+		},
+		{
+			name:   "retry after date",
+			method: http.MethodGet,
+			steps: []func(*http.Request) (*http.Response, error){
+				respond(503, "Retry-After", start.Add(90*time.Second).Format(http.TimeFormat)), respond(200),
+			},
+			wantStatus: 200,
+			wantSleeps: []time.Duration{90 * time.Second},
+		},
+		{
+			name:   "retry after too long",
+			method: http.MethodGet,
+			steps: []func(*http.Request) (*http.Response, error){
+				respond(429, "Retry-After", "3600"), respond(200),
+			},
+			wantStatus: 429,
+		},
+		{
+			name:       "not retryable",
+			method:     http.MethodGet,
+			steps:      []func(*http.Request) (*http.Response, error){respond(500), respond(200)},
+			wantStatus: 500,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clock := &fakeClock{now: start}
+			script := &scriptedTransport{steps: c.steps}
+			rt := &RetryTransport{
+				Base:   script,
+				Clock:  clock,
+				jitter: func(time.Duration) time.Duration { return 0 },
+			}
+			req, err := http.NewRequest(c.method, "https://api.example.com/items", strings.NewReader("body"))
+			require.NoError(t, err)
+
+			resp, err := rt.RoundTrip(req)
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				defer resp.Body.Close()
+				assert.Equal(t, c.wantStatus, resp.StatusCode)
+			}
+			assert.Equal(t, c.wantSleeps, clock.sleeps)
+
+			// The body is sent again with each attempt.
+			for _, b := range script.bodies {
+				assert.Equal(t, "body", b)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_Jitter(t *testing.T) {
+	rt := &RetryTransport{MaxBackoff: 4 * time.Second}
+	for attempt := range 10 {
+		backoff := min(defaultMinBackoff<<attempt, 4*time.Second)
+		d, ok := rt.delay(attempt, nil)
+		assert.True(t, ok)
+		assert.GreaterOrEqual(t, d, backoff/2)
+		assert.Less(t, d, backoff)
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	rt := &RateLimitTransport{
+		Base:  &scriptedTransport{steps: []func(*http.Request) (*http.Response, error){respond(200)}},
+		Clock: clock,
+		Rate:  2,
+		Burst: 2,
+	}
+	do := func(urlStr string) {
+		req, err := http.NewRequest(http.MethodGet, urlStr, http.NoBody)
+		require.NoError(t, err)
+		resp, err := rt.RoundTrip(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// The burst is allowed immediately, then requests wait for the bucket to refill.
+	for range 4 {
+		do("https://a.example.com/")
+	}
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, 500 * time.Millisecond}, clock.sleeps)
+
+	// Each host has its own bucket.
+	do("https://b.example.com/")
+	assert.Len(t, clock.sleeps, 2)
+}
+
+func TestNewTransport_Client(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": [{"id": "a"}]}`))
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Now()}
+	client, err := NewClient(server.URL, &http.Client{Transport: NewTransport(nil, clock)})
+	require.NoError(t, err)
+
+	items, err := ListAll[testItem](context.Background(), client, "/items")
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, 2, calls)
+	assert.Len(t, clock.sleeps, 1)
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Project roles.
+const (
+	ProjectRoleAdmin  = "admin"
+	ProjectRoleViewer = "viewer"
+)
+
+// Environment type roles.
+const (
+	EnvironmentRoleAdmin       = "admin"
+	EnvironmentRoleContributor = "contributor"
+	EnvironmentRoleViewer      = "viewer"
+)
+
+// ProjectRoleNames lists the possible project roles.
+var ProjectRoleNames = []string{ProjectRoleAdmin, ProjectRoleViewer}
+
+// EnvironmentRoleNames lists the possible environment type roles.
+var EnvironmentRoleNames = []string{EnvironmentRoleAdmin, EnvironmentRoleContributor, EnvironmentRoleViewer}
+
+// EnvironmentTypes lists the environment types which can have their own roles.
+var EnvironmentTypes = []string{"production", "staging", "development"}
+
+// ProjectRoles are a user's roles on a project: a project role, and
+// optionally a role for each environment type.
+type ProjectRoles struct {
+	Project          string
+	EnvironmentTypes map[string]string
+}
+
+// ParseProjectRoles parses a list of permissions, such as "viewer" or
+// "production:contributor", into project roles.
+func ParseProjectRoles(permissions []string) ProjectRoles {
+	r := ProjectRoles{Project: ProjectRoleViewer, EnvironmentTypes: map[string]string{}}
+	for _, p := range permissions {
+		if envType, role, ok := strings.Cut(p, ":"); ok {
+			r.EnvironmentTypes[envType] = role
+		} else if p == ProjectRoleAdmin {
+			r.Project = ProjectRoleAdmin
+		}
+	}
+	return r
+}
+
+// EnvironmentTypeRole returns the user's role on an environment type, or an
+// empty string if they have none. Project admins are admins of every type.
+func (r ProjectRoles) EnvironmentTypeRole(envType string) string {
+	if r.Project == ProjectRoleAdmin {
+		return EnvironmentRoleAdmin
+	}
+	return r.EnvironmentTypes[envType]
+}
+
+// Permissions formats the roles as a list of permissions for the API.
+func (r ProjectRoles) Permissions() []string {
+	if r.Project == ProjectRoleAdmin {
+		return []string{ProjectRoleAdmin}
+	}
+	permissions := []string{ProjectRoleViewer}
+	envTypes := make([]string, 0, len(r.EnvironmentTypes))
+	for t := range r.EnvironmentTypes {
+		envTypes = append(envTypes, t)
+	}
+	slices.Sort(envTypes)
+	for _, t := range envTypes {
+		if role := r.EnvironmentTypes[t]; role != "" {
+			permissions = append(permissions, t+":"+role)
+		}
+	}
+	return permissions
+}
+
+// ProjectAccess is a user's access to a project.
+type ProjectAccess struct {
+	ProjectID      string    `json:"project_id"`
+	OrganizationID string    `json:"organization_id"`
+	UserID         string    `json:"user_id"`
+	Permissions    []string  `json:"permissions"`
+	GrantedAt      time.Time `json:"granted_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	// User is the user account, resolved from references, when listing a project's users.
+	User *User `json:"-"`
+	// Project is the project, resolved from references, when listing a user's projects.
+	Project *ProjectRef `json:"-"`
+}
+
+// Roles returns the user's roles on the project.
+func (a *ProjectAccess) Roles() ProjectRoles {
+	return ParseProjectRoles(a.Permissions)
+}
+
+// ListProjectUserAccess lists the users who can access a project, with their user accounts.
+func (c *Client) ListProjectUserAccess(ctx context.Context, projectID string) ([]*ProjectAccess, error) {
+	u, err := c.baseURLWithSegments("projects", projectID, "user-access")
+	if err != nil {
+		return nil, err
+	}
+	var (
+		access   []*ProjectAccess
+		refLinks []string
+	)
+	for urlStr := u.String(); urlStr != ""; {
+		var page collectionPage[*ProjectAccess]
+		if err := c.getResource(ctx, urlStr, &page); err != nil {
+			return nil, err
+		}
+		access = append(access, page.Items...)
+		for rel := range page.Links {
+			if strings.HasPrefix(rel, "ref:users:") {
+				href, _ := page.Links.GetLink(rel)
+				refLinks = append(refLinks, href)
+			}
+		}
+		urlStr, _ = page.Links.GetLink("next")
+	}
+	users, err := getRefs[User](ctx, c, refLinks)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range access {
+		a.User = users[a.UserID]
+	}
+	return access, nil
+}
+
+// ListUserProjectAccess lists a user's access to each of their projects.
+// Projects which no longer exist are skipped.
+func (c *Client) ListUserProjectAccess(ctx context.Context, userID string) ([]*ProjectAccess, error) {
+	grants, refLinks, err := c.listUserProjectGrants(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	projectRefs, err := getRefs[ProjectRef](ctx, c, refLinks["projects"])
+	if err != nil {
+		return nil, err
+	}
+	access := make([]*ProjectAccess, 0, len(grants))
+	for _, g := range grants {
+		ref := projectRefs[g.ResourceID]
+		if ref == nil {
+			continue
+		}
+		access = append(access, &ProjectAccess{
+			ProjectID:      g.ResourceID,
+			OrganizationID: g.OrganizationID,
+			UserID:         userID,
+			Permissions:    g.Permissions,
+			GrantedAt:      g.GrantedAt,
+			UpdatedAt:      g.UpdatedAt,
+			Project:        ref,
+		})
+	}
+	return access, nil
+}
+
+// UpdateProjectUserAccess sets a user's permissions on a project.
+func (c *Client) UpdateProjectUserAccess(
+	ctx context.Context,
+	projectID, userID string,
+	permissions []string,
+) (*ProjectAccess, error) {
+	u, err := c.baseURLWithSegments("projects", projectID, "user-access", userID)
+	if err != nil {
+		return nil, err
+	}
+	var a ProjectAccess
+	if err := c.request(ctx, http.MethodPatch, u.String(), map[string]any{"permissions": permissions}, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// DeleteProjectUserAccess removes a user's access to a project.
+func (c *Client) DeleteProjectUserAccess(ctx context.Context, projectID, userID string) error {
+	u, err := c.baseURLWithSegments("projects", projectID, "user-access", userID)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, http.MethodDelete, u.String(), nil, nil)
+}
+
+// ProjectInvitation is an invitation for a user to join a project.
+type ProjectInvitation struct {
+	ID          string                        `json:"id"`
+	State       string                        `json:"state"`
+	Email       string                        `json:"email"`
+	Role        string                        `json:"role"`
+	Permissions []ProjectInvitationPermission `json:"permissions"`
+	CreatedAt   time.Time                     `json:"created_at"`
+}
+
+// ProjectInvitationPermission is an environment type role in a project invitation.
+type ProjectInvitationPermission struct {
+	Type string `json:"type"`
+	Role string `json:"role"`
+}
+
+// InviteProjectUser invites a user, by email address, to join a project with the given roles.
+func (c *Client) InviteProjectUser(
+	ctx context.Context,
+	projectID, email string,
+	roles ProjectRoles,
+) (*ProjectInvitation, error) {
+	u, err := c.baseURLWithSegments("projects", projectID, "invitations")
+	if err != nil {
+		return nil, err
+	}
+	permissions := []ProjectInvitationPermission{}
+	if roles.Project != ProjectRoleAdmin {
+		for _, t := range EnvironmentTypes {
+			if role := roles.EnvironmentTypes[t]; role != "" {
+				permissions = append(permissions, ProjectInvitationPermission{Type: t, Role: role})
+			}
+		}
+	}
+	return Create[ProjectInvitation](ctx, c, u.String(),
+		map[string]any{"email": email, "role": roles.Project, "permissions": permissions})
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Variable levels.
+const (
+	VariableLevelProject     = "project"
+	VariableLevelEnvironment = "environment"
+)
+
+// Variable is a project-level or environment-level variable.
+type Variable struct {
+	Name             string    `json:"name"`
+	Value            string    `json:"value,omitempty"`
+	IsJSON           bool      `json:"is_json"`
+	IsSensitive      bool      `json:"is_sensitive"`
+	VisibleBuild     bool      `json:"visible_build"`
+	VisibleRuntime   bool      `json:"visible_runtime"`
+	ApplicationScope []string  `json:"application_scope,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Environment-level variables only.
+	IsEnabled     bool `json:"is_enabled"`
+	Inherited     bool `json:"inherited"`
+	IsInheritable bool `json:"is_inheritable"`
+
+	// Level is the level of the variable: VariableLevelProject or VariableLevelEnvironment.
+	Level string `json:"-"`
+
+	HALResource
+}
+
+// variablesURL returns the URL of the project-level variables collection, or,
+// if an environment ID is given, the environment-level variables collection.
+func (c *Client) variablesURL(projectID, environmentID string, name ...string) (*url.URL, error) {
+	if environmentID == "" {
+		return c.baseURLWithSegments(append([]string{"projects", projectID, "variables"}, name...)...)
+	}
+	segments := []string{"projects", projectID, "environments", environmentID, "variables"}
+	return c.baseURLWithSegments(append(segments, name...)...)
+}
+
+func variableLevel(environmentID string) string {
+	if environmentID == "" {
+		return VariableLevelProject
+	}
+	return VariableLevelEnvironment
+}
+
+// ListVariables lists the project-level variables, or, if an environment ID
+// is given, the environment-level variables (including inherited ones).
+func (c *Client) ListVariables(ctx context.Context, projectID, environmentID string) ([]*Variable, error) {
+	u, err := c.variablesURL(projectID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := ListAll[*Variable](ctx, c, u.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vars {
+		v.Level = variableLevel(environmentID)
+	}
+	return vars, nil
+}
+
+// GetVariable gets a project-level variable, or an environment-level one if an environment ID is given.
+func (c *Client) GetVariable(ctx context.Context, projectID, environmentID, name string) (*Variable, error) {
+	u, err := c.variablesURL(projectID, environmentID, name)
+	if err != nil {
+		return nil, err
+	}
+	v, err := Get[Variable](ctx, c, u.String())
+	if err != nil {
+		return nil, err
+	}
+	v.Level = variableLevel(environmentID)
+	return v, nil
+}
+
+// GetVariableProperties gets a variable as a map of its raw properties.
+func (c *Client) GetVariableProperties(
+	ctx context.Context,
+	projectID, environmentID, name string,
+) (map[string]any, error) {
+	u, err := c.variablesURL(projectID, environmentID, name)
+	if err != nil {
+		return nil, err
+	}
+	props, err := Get[map[string]any](ctx, c, u.String())
+	if err != nil {
+		return nil, err
+	}
+	return *props, nil
+}
+
+// CreateVariable creates a variable with the given fields, returning any activities started.
+func (c *Client) CreateVariable(
+	ctx context.Context,
+	projectID, environmentID string,
+	fields map[string]any,
+) ([]*Activity, error) {
+	u, err := c.variablesURL(projectID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	var r activitiesResult
+	if err := c.request(ctx, http.MethodPost, u.String(), fields, &r); err != nil {
+		return nil, err
+	}
+	return r.Embedded.Activities, nil
+}
+
+// UpdateVariable updates the given fields of a variable, returning any activities started.
+func (c *Client) UpdateVariable(
+	ctx context.Context,
+	projectID, environmentID, name string,
+	fields map[string]any,
+) ([]*Activity, error) {
+	u, err := c.variablesURL(projectID, environmentID, name)
+	if err != nil {
+		return nil, err
+	}
+	var r activitiesResult
+	if err := c.request(ctx, http.MethodPatch, u.String(), fields, &r); err != nil {
+		return nil, err
+	}
+	return r.Embedded.Activities, nil
+}
+
+// DeleteVariable deletes a variable, returning any activities started.
+func (c *Client) DeleteVariable(ctx context.Context, projectID, environmentID, name string) ([]*Activity, error) {
+	u, err := c.variablesURL(projectID, environmentID, name)
+	if err != nil {
+		return nil, err
+	}
+	var r activitiesResult
+	if err := c.request(ctx, http.MethodDelete, u.String(), nil, &r); err != nil {
+		return nil, err
+	}
+	return r.Embedded.Activities, nil
+}
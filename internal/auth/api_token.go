@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+
+	"github.com/platformsh/cli/internal/config"
+)
+
+// APITokenFromEnv returns the API token set in the {ENV_PREFIX}TOKEN environment variable, if any.
+func APITokenFromEnv(cnf *config.Config) string {
+	return strings.TrimSpace(os.Getenv(cnf.Application.EnvPrefix + "TOKEN"))
+}
+
+// IdentityKey returns a key identifying the user whose credentials are used for
+// API requests, for data stored per user: the API token from the environment
+// if one is set, otherwise the user of the session (see SessionStore.CacheKey).
+// It is empty if there are no credentials.
+func IdentityKey(cnf *config.Config) (string, error) {
+	// An API token may belong to a different user from the session.
+	if apiToken := APITokenFromEnv(cnf); apiToken != "" {
+		h := sha256.Sum256([]byte(apiToken))
+		return "token-" + hex.EncodeToString(h[:8]), nil
+	}
+	store, err := NewSessionStore(cnf)
+	if err != nil {
+		return "", err
+	}
+	return store.CacheKey(), nil
+}
+
+// apiTokenSource is a token source that exchanges an API token for access tokens,
+// using the "api_token" grant type.
+//
+// Access tokens are cached in memory and in a session file dedicated to the API
+// token, until they expire.
+type apiTokenSource struct {
+	ctx      context.Context
+	apiToken string
+	tokenURL string
+	clientID string
+	store    *SessionStore
+
+	cached *oauth2.Token
+	mu     sync.Mutex
+}
+
+// NewAPITokenSource creates a token source which exchanges an API token for access tokens.
+func NewAPITokenSource(ctx context.Context, cnf *config.Config, apiToken string) (oauth2.TokenSource, error) {
+	if apiToken == "" {
+		return nil, errors.New("no API token provided")
+	}
+	if cnf.API.OAuth2TokenURL == "" {
+		return nil, errors.New("no OAuth2 token URL configured")
+	}
+	writableDir, err := cnf.WritableUserDir()
+	if err != nil {
+		return nil, err
+	}
+
+	// Each API token has its own session file, identified by a hash of the token.
+	hash := sha256.Sum256([]byte(apiToken))
+
+	return &apiTokenSource{
+		ctx:      ctx,
+		apiToken: apiToken,
+		tokenURL: cnf.API.OAuth2TokenURL,
+		clientID: cnf.API.OAuth2ClientID,
+		store:    newSessionStore(writableDir, "api-token-"+hex.EncodeToString(hash[:16])),
+	}, nil
+}
+
+func (ts *apiTokenSource) Token() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.cached != nil && ts.cached.Valid() {
+		return ts.cached, nil
+	}
+
+	unlock, err := ts.store.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if tok, err := ts.store.Load(); err == nil && tok.Valid() {
+		ts.cached = tok
+		return tok, nil
+	}
+
+	if err := ts.unsafeExchange(); err != nil {
+		return nil, err
+	}
+
+	return ts.cached, nil
+}
+
+func (ts *apiTokenSource) refreshToken() error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	unlock, err := ts.store.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return ts.unsafeExchange()
+}
+
+func (ts *apiTokenSource) invalidateToken() error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.cached = nil
+
+	return ts.store.Delete()
+}
+
+// unsafeExchange exchanges the API token for a new access token, and saves it.
+// The caller must hold both the mutex and the session lock.
+func (ts *apiTokenSource) unsafeExchange() error {
+	tok, err := exchangeAPIToken(oauthContext(ts.ctx), ts.tokenURL, ts.clientID, ts.apiToken)
+	if err != nil {
+		return err
+	}
+	if err := ts.store.Save(tok); err != nil {
+		return fmt.Errorf("cannot save access token: %w", err)
+	}
+	ts.cached = tok
+
+	return nil
+}
+
+// exchangeAPIToken requests an access token using the "api_token" grant type.
+func exchangeAPIToken(ctx context.Context, tokenURL, clientID, apiToken string) (*oauth2.Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "api_token")
+	form.Set("api_token", apiToken)
+	if clientID != "" {
+		form.Set("client_id", clientID)
+	}
+	tok, err := requestToken(ctx, tokenURL, form)
+	if err != nil {
+		return nil, fmt.Errorf("cannot exchange API token: %w", err)
+	}
+	return tok, nil
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/internal/legacy"
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+type countingTransport struct {
+	count atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.count.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestAPITokenSource(t *testing.T) {
+	authServer := mockapi.NewAuthServer(t)
+	defer authServer.Close()
+
+	cnf := testConfig(t, authServer.URL)
+	rt := &countingTransport{}
+	ctx := WithTransport(context.Background(), rt)
+
+	ts, err := NewAPITokenSource(ctx, cnf, "invalid-token")
+	require.NoError(t, err)
+	_, err = ts.Token()
+	assert.ErrorContains(t, err, "invalid API token")
+
+	ts, err = NewAPITokenSource(ctx, cnf, mockapi.ValidAPITokens[0])
+	require.NoError(t, err)
+	tok, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access-token-1", tok.AccessToken)
+
+	// The token is cached in memory.
+	_, err = ts.Token()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, rt.count.Load())
+
+	// The token is cached on disk for other token sources.
+	ts2, err := NewAPITokenSource(ctx, cnf, mockapi.ValidAPITokens[0])
+	require.NoError(t, err)
+	tok2, err := ts2.Token()
+	require.NoError(t, err)
+	assert.Equal(t, tok.AccessToken, tok2.AccessToken)
+	assert.EqualValues(t, 2, rt.count.Load())
+}
+
+func TestLegacyCLIClient_APIToken(t *testing.T) {
+	authServer := mockapi.NewAuthServer(t)
+	defer authServer.Close()
+
+	apiHandler := mockapi.NewHandler(t)
+	apiHandler.SetMyUser(&mockapi.User{ID: "my-user-id"})
+	apiServer := httptest.NewServer(apiHandler)
+	defer apiServer.Close()
+
+	cnf := testConfig(t, authServer.URL)
+	t.Setenv(cnf.Application.EnvPrefix+"TOKEN", mockapi.ValidAPITokens[0])
+
+	client, err := NewLegacyCLIClient(context.Background(), &legacy.CLIWrapper{Config: cnf})
+	require.NoError(t, err)
+	require.NoError(t, client.EnsureAuthenticated(context.Background()))
+
+	resp, err := client.HTTPClient.Get(apiServer.URL + "/users/me")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
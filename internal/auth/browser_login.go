@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+)
+
+// BrowserLoginOptions configures BrowserLogin.
+type BrowserLoginOptions struct {
+	// NoBrowser disables opening a browser: the authorization URL is only printed.
+	NoBrowser bool
+
+	// OpenBrowser opens a URL in a browser. It defaults to an OS-specific command.
+	OpenBrowser func(url string) error
+
+	// ListenAddr is the loopback address for the redirect server. By default,
+	// the first available port between 5000 and 5010 is used.
+	ListenAddr string
+
+	// Timeout is how long to wait for the user to log in, defaulting to 5 minutes.
+	Timeout time.Duration
+
+	// Stderr receives messages for the user.
+	Stderr io.Writer
+}
+
+// BrowserLogin logs in via the OAuth2 authorization code flow with PKCE.
+//
+// It runs a local HTTP server to receive the redirect from the authorization
+// server, exchanges the code for tokens, and saves them in the configured session.
+func BrowserLogin(ctx context.Context, cnf *config.Config, opts *BrowserLoginOptions) (*oauth2.Token, error) {
+	if cnf.API.OAuth2AuthorizeURL == "" || cnf.API.OAuth2TokenURL == "" {
+		return nil, errors.New("the OAuth2 authorization and token URLs are not configured")
+	}
+	if opts.Stderr == nil {
+		opts.Stderr = io.Discard
+	}
+	if opts.OpenBrowser == nil {
+		opts.OpenBrowser = openBrowser
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Minute
+	}
+
+	listener, err := listenLoopback(opts.ListenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("could not start a local server for the login redirect: %w", err)
+	}
+	defer listener.Close()
+
+	var (
+		verifier  = oauth2.GenerateVerifier()
+		state     = oauth2.GenerateVerifier()
+		oauthConf = oauthConfig(cnf)
+		codeChan  = make(chan string, 1)
+		errChan   = make(chan error, 1)
+		sendErr   = func(err error) {
+			select {
+			case errChan <- err:
+			default:
+			}
+		}
+	)
+	oauthConf.RedirectURL = "http://" + listener.Addr().String()
+
+	server := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			q := req.URL.Query()
+			switch {
+			case q.Get("error") != "":
+				writeLoginPage(w, http.StatusBadRequest, "Login failed", q.Get("error_description"))
+				sendErr(fmt.Errorf("login failed: %s", q.Get("error")))
+			case q.Get("state") != state:
+				writeLoginPage(w, http.StatusBadRequest, "Login failed", "Invalid state parameter.")
+				sendErr(errors.New("login failed: invalid state parameter"))
+			case q.Get("code") == "":
+				writeLoginPage(w, http.StatusBadRequest, "Login failed", "No authorization code was received.")
+				sendErr(errors.New("login failed: no authorization code received"))
+			default:
+				writeLoginPage(w, http.StatusOK, "Successfully logged in",
+					"You can close this page and return to your terminal.")
+				select {
+				case codeChan <- q.Get("code"):
+				default:
+				}
+			}
+		}),
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			sendErr(err)
+		}
+	}()
+	defer server.Close()
+
+	authURL := oauthConf.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier))
+
+	fmt.Fprintln(opts.Stderr, "Please open the following URL in a browser and log in:")
+	fmt.Fprintln(opts.Stderr, authURL)
+	fmt.Fprintln(opts.Stderr)
+	if !opts.NoBrowser {
+		if err := opts.OpenBrowser(authURL); err != nil {
+			fmt.Fprintln(opts.Stderr, "Could not open a browser:", err)
+		}
+	}
+	fmt.Fprintln(opts.Stderr, "Waiting for login...")
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	var code string
+	select {
+	case code = <-codeChan:
+	case err := <-errChan:
+		return nil, err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("login timed out: %w", ctx.Err())
+	}
+
+	tok, err := oauthConf.Exchange(oauthContext(ctx), code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("could not exchange authorization code: %w", err)
+	}
+
+	if err := saveSessionToken(cnf, tok); err != nil {
+		return nil, err
+	}
+
+	return tok, nil
+}
+
+// saveSessionToken saves a token in the configured session store.
+func saveSessionToken(cnf *config.Config, tok *oauth2.Token) error {
+	store, err := NewSessionStore(cnf)
+	if err != nil {
+		return err
+	}
+	unlock, err := store.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := store.Save(tok); err != nil {
+		return fmt.Errorf("could not save session: %w", err)
+	}
+	// The user may have changed, so the session's cached API responses are discarded.
+	return api.ClearCache(cnf, currentSessionID(cnf))
+}
+
+// listenLoopback listens on a loopback address, trying a range of ports if none is specified.
+func listenLoopback(addr string) (net.Listener, error) {
+	if addr != "" {
+		return net.Listen("tcp", addr)
+	}
+	var err error
+	for port := 5000; port <= 5010; port++ {
+		var l net.Listener
+		l, err = net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		if err == nil {
+			return l, nil
+		}
+	}
+	return nil, err
+}
+
+func writeLoginPage(w http.ResponseWriter, status int, title, content string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, "<!DOCTYPE html>\n<html><head><title>%[1]s</title></head>"+
+		"<body><h1>%[1]s</h1><p>%[2]s</p></body></html>\n",
+		html.EscapeString(title), html.EscapeString(content))
+}
+
+// openBrowser opens a URL using the operating system's default browser.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+func TestBrowserLogin(t *testing.T) {
+	authServer := mockapi.NewAuthServer(t)
+	defer authServer.Close()
+
+	cnf := testConfig(t, authServer.URL)
+	cnf.API.OAuth2AuthorizeURL = authServer.URL + "/oauth2/authorize"
+
+	// Imitate a browser by following the redirects back to the local server.
+	var pageStatus atomic.Int32
+	openBrowser := func(url string) error {
+		go func() {
+			resp, err := http.Get(url) //nolint:gosec // test URL
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer resp.Body.Close()
+			_, _ = io.Copy(io.Discard, resp.Body)
+			pageStatus.Store(int32(resp.StatusCode)) //nolint:gosec // HTTP status codes fit
+		}()
+		return nil
+	}
+
+	tok, err := BrowserLogin(context.Background(), cnf, &BrowserLoginOptions{
+		OpenBrowser: openBrowser,
+		ListenAddr:  "127.0.0.1:0",
+		Timeout:     10 * time.Second,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "access-token-1", tok.AccessToken)
+	assert.Equal(t, "refresh-token-1", tok.RefreshToken)
+
+	store, err := NewSessionStore(cnf)
+	require.NoError(t, err)
+	saved, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, tok.AccessToken, saved.AccessToken)
+	assert.Equal(t, tok.RefreshToken, saved.RefreshToken)
+
+	assert.Eventually(t, func() bool { return pageStatus.Load() == http.StatusOK }, time.Second, 10*time.Millisecond)
+}
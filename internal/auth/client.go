@@ -7,6 +7,7 @@ import (
 
 	"golang.org/x/oauth2"
 
+	"github.com/platformsh/cli/internal/httpclient"
 	"github.com/platformsh/cli/internal/legacy"
 )
 
@@ -20,19 +21,36 @@ func (c *LegacyCLIClient) EnsureAuthenticated(_ context.Context) error {
 	return err
 }
 
-// NewLegacyCLIClient creates an HTTP client authenticated through the legacy CLI.
+// NewLegacyCLIClient creates an HTTP client authenticated through the legacy CLI's session.
+// An API token set in the environment is exchanged natively. Otherwise, tokens
+// are read and refreshed natively when the session can be read, and the legacy
+// CLI itself is used as a fallback.
 // The wrapper argument must be a dedicated wrapper, not used by other callers.
 func NewLegacyCLIClient(ctx context.Context, wrapper *legacy.CLIWrapper) (*LegacyCLIClient, error) {
-	ts, err := NewLegacyCLITokenSource(ctx, wrapper)
+	var (
+		ts  oauth2.TokenSource
+		err error
+	)
+	if apiToken := APITokenFromEnv(wrapper.Config); apiToken != "" {
+		ts, err = NewAPITokenSource(ctx, wrapper.Config, apiToken)
+	} else {
+		ts, err = NewSessionTokenSource(ctx, wrapper.Config)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("oauth2: create token source: %w", err)
+		if wrapper.DebugLogFunc != nil {
+			wrapper.DebugLogFunc("Falling back to the legacy CLI for authentication: %s", err)
+		}
+		ts, err = NewLegacyCLITokenSource(ctx, wrapper)
+		if err != nil {
+			return nil, fmt.Errorf("oauth2: create token source: %w", err)
+		}
 	}
 
 	refresher, ok := ts.(refresher)
 	if !ok {
 		return nil, fmt.Errorf("token source does not implement refresher")
 	}
-	baseRT := http.DefaultTransport
+	baseRT := httpclient.NewTransport(wrapper.Config)
 	if rt, ok := TransportFromContext(ctx); ok && rt != nil {
 		baseRT = rt
 	}
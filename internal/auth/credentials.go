@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofrs/flock"
+	"github.com/zalando/go-keyring"
+
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/file"
+)
+
+// ErrCredentialNotFound is returned by a CredentialStore when a key does not exist.
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialStore stores secrets, such as refresh tokens, by key.
+type CredentialStore interface {
+	// Get returns the secret for a key, or ErrCredentialNotFound.
+	Get(key string) (string, error)
+	// Set creates or replaces the secret for a key.
+	Set(key, secret string) error
+	// Delete removes the secret for a key. It is not an error if the key does not exist.
+	Delete(key string) error
+	// Name describes the store for users.
+	Name() string
+}
+
+// Credential store types, selected with the "api.credential_store" config key
+// or the {ENV_PREFIX}CREDENTIAL_STORE environment variable. With any store other
+// than the session file, refresh tokens are removed from the session file, so
+// the legacy CLI cannot refresh tokens itself: it uses the access token.
+const (
+	// CredentialStoreSession keeps refresh tokens only in the session file. This is the default.
+	CredentialStoreSession = "session"
+	// CredentialStoreKeyring uses the OS keyring: the Secret Service on Linux, the
+	// Keychain on macOS, or the Credential Manager on Windows.
+	CredentialStoreKeyring = "keyring"
+	// CredentialStoreFile uses a separate file, only readable by the user.
+	CredentialStoreFile = "file"
+	// CredentialStoreAuto uses the OS keyring if it is reachable, or a file otherwise.
+	CredentialStoreAuto = "auto"
+)
+
+// NewCredentialStore returns the configured credential store. It returns nil
+// if credentials should only be kept in the session file.
+func NewCredentialStore(cnf *config.Config) (CredentialStore, error) {
+	storeType := os.Getenv(cnf.Application.EnvPrefix + "CREDENTIAL_STORE")
+	if storeType == "" {
+		storeType = cnf.API.CredentialStore
+	}
+	switch storeType {
+	case "", CredentialStoreSession:
+		return nil, nil
+	case CredentialStoreKeyring:
+		ks := newKeyringStore(cnf)
+		if err := ks.probe(); err != nil {
+			return nil, fmt.Errorf("the OS keyring is not available: %w", err)
+		}
+		return ks, nil
+	case CredentialStoreFile:
+		return newFileStore(cnf)
+	case CredentialStoreAuto:
+		ks := newKeyringStore(cnf)
+		if err := ks.probe(); err == nil {
+			return ks, nil
+		}
+		return newFileStore(cnf)
+	default:
+		return nil, fmt.Errorf("unknown credential store type: %s", storeType)
+	}
+}
+
+// keyringStore stores credentials in the OS keyring.
+type keyringStore struct {
+	service string
+}
+
+func newKeyringStore(cnf *config.Config) *keyringStore {
+	return &keyringStore{service: cnf.Application.Slug}
+}
+
+// probe checks whether the keyring is reachable.
+func (k *keyringStore) probe() error {
+	_, err := keyring.Get(k.service, "probe")
+	if err == nil || errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (k *keyringStore) Get(key string) (string, error) {
+	secret, err := keyring.Get(k.service, key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrCredentialNotFound
+	}
+	return secret, err
+}
+
+func (k *keyringStore) Set(key, secret string) error {
+	return keyring.Set(k.service, key, secret)
+}
+
+func (k *keyringStore) Delete(key string) error {
+	if err := keyring.Delete(k.service, key); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (k *keyringStore) Name() string {
+	return "OS keyring"
+}
+
+// fileStore stores credentials in a JSON file, with permissions restricted to the user.
+type fileStore struct {
+	path string
+}
+
+func newFileStore(cnf *config.Config) (*fileStore, error) {
+	writableDir, err := cnf.WritableUserDir()
+	if err != nil {
+		return nil, err
+	}
+	return &fileStore{path: filepath.Join(writableDir, "credentials.json")}, nil
+}
+
+func (f *fileStore) Get(key string) (string, error) {
+	creds, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := creds[key]
+	if !ok {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (f *fileStore) Set(key, secret string) error {
+	return f.update(func(creds map[string]string) { creds[key] = secret })
+}
+
+func (f *fileStore) Delete(key string) error {
+	return f.update(func(creds map[string]string) { delete(creds, key) })
+}
+
+func (f *fileStore) Name() string {
+	return "file: " + f.path
+}
+
+func (f *fileStore) read() (map[string]string, error) {
+	creds := make(map[string]string)
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return creds, nil
+		}
+		return nil, err
+	}
+	if strings.TrimSpace(string(b)) == "" {
+		return creds, nil
+	}
+	if err := json.Unmarshal(b, &creds); err != nil {
+		return nil, fmt.Errorf("could not parse credentials file %s: %w", f.path, err)
+	}
+	return creds, nil
+}
+
+// update modifies the credentials file while holding a lock, shared across processes.
+func (f *fileStore) update(fn func(creds map[string]string)) error {
+	fileLock := flock.New(f.path + ".lock")
+	if err := fileLock.Lock(); err != nil {
+		return fmt.Errorf("could not acquire credentials lock: %w", err)
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	creds, err := f.read()
+	if err != nil {
+		return err
+	}
+	fn(creds)
+	b, err := json.Marshal(creds)
+	if err != nil {
+		return err
+	}
+	return file.Write(f.path, b, 0o600)
+}
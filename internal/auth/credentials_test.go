@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+	"golang.org/x/oauth2"
+)
+
+func TestCredentialStores(t *testing.T) {
+	keyring.MockInit()
+
+	for _, storeType := range []string{CredentialStoreKeyring, CredentialStoreFile, CredentialStoreAuto} {
+		t.Run(storeType, func(t *testing.T) {
+			cnf := testConfig(t, "http://127.0.0.1")
+			cnf.API.CredentialStore = storeType
+
+			creds, err := NewCredentialStore(cnf)
+			require.NoError(t, err)
+			require.NotNil(t, creds)
+
+			_, err = creds.Get("key")
+			assert.ErrorIs(t, err, ErrCredentialNotFound)
+			require.NoError(t, creds.Set("key", "secret"))
+			secret, err := creds.Get("key")
+			require.NoError(t, err)
+			assert.Equal(t, "secret", secret)
+			require.NoError(t, creds.Delete("key"))
+			require.NoError(t, creds.Delete("key"))
+			_, err = creds.Get("key")
+			assert.ErrorIs(t, err, ErrCredentialNotFound)
+		})
+	}
+}
+
+func TestCredentialStore_Fallback(t *testing.T) {
+	keyring.MockInitWithError(keyring.ErrUnsupportedPlatform)
+	t.Cleanup(keyring.MockInit)
+
+	cnf := testConfig(t, "http://127.0.0.1")
+
+	cnf.API.CredentialStore = CredentialStoreKeyring
+	_, err := NewCredentialStore(cnf)
+	assert.Error(t, err)
+
+	cnf.API.CredentialStore = CredentialStoreAuto
+	creds, err := NewCredentialStore(cnf)
+	require.NoError(t, err)
+	assert.IsType(t, &fileStore{}, creds)
+
+	// The keyring is not probed until a session store needs it.
+	cnf.API.CredentialStore = CredentialStoreKeyring
+	store, err := NewSessionStore(cnf)
+	require.NoError(t, err)
+	assert.Error(t, store.Save(&oauth2.Token{AccessToken: "access-token", RefreshToken: "refresh-token"}))
+
+	// The environment variable overrides the config.
+	t.Setenv(cnf.Application.EnvPrefix+"CREDENTIAL_STORE", CredentialStoreSession)
+	creds, err = NewCredentialStore(cnf)
+	require.NoError(t, err)
+	assert.Nil(t, creds)
+}
+
+func TestSessionStore_CredentialStore(t *testing.T) {
+	keyring.MockInit()
+
+	cnf := testConfig(t, "http://127.0.0.1")
+	cnf.API.CredentialStore = CredentialStoreKeyring
+
+	store, err := NewSessionStore(cnf)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(&oauth2.Token{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		Expiry:       time.Now().Add(time.Hour),
+	}))
+
+	// The refresh token is saved in the keyring, and not in the session file.
+	b, err := os.ReadFile(store.path())
+	require.NoError(t, err)
+	var data map[string]any
+	require.NoError(t, json.Unmarshal(b, &data))
+	assert.Equal(t, "access-token", data["accessToken"])
+	assert.NotContains(t, data, "refreshToken")
+	secret, err := keyring.Get(cnf.Application.Slug, store.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-token", secret)
+
+	tok, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-token", tok.RefreshToken)
+
+	// A refresh token written to the session file, e.g. by the legacy CLI, is moved to the keyring.
+	require.NoError(t, os.WriteFile(store.path(), []byte(`{"accessToken":"access-token",`+
+		`"refreshToken":"legacy-refresh-token"}`), 0o600))
+	tok, err = store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-refresh-token", tok.RefreshToken)
+	require.NoError(t, store.Save(tok))
+	b, err = os.ReadFile(store.path())
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "refreshToken")
+	secret, err = keyring.Get(cnf.Application.Slug, store.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-refresh-token", secret)
+
+	require.NoError(t, store.Delete())
+	_, err = store.Load()
+	assert.ErrorIs(t, err, ErrNoSession)
+	_, err = keyring.Get(cnf.Application.Slug, store.ID)
+	assert.ErrorIs(t, err, keyring.ErrNotFound)
+
+	// The same applies to the file store.
+	cnf.API.CredentialStore = CredentialStoreFile
+	store, err = NewSessionStore(cnf)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(&oauth2.Token{AccessToken: "access-token", RefreshToken: "refresh-token"}))
+	b, err = os.ReadFile(store.path())
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "refresh-token")
+	tok, err = store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-token", tok.RefreshToken)
+}
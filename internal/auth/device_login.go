@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/platformsh/cli/internal/config"
+)
+
+// DeviceLoginOptions configures DeviceLogin.
+type DeviceLoginOptions struct {
+	// Stderr receives messages for the user.
+	Stderr io.Writer
+}
+
+var (
+	// ErrDeviceCodeExpired is returned when the user did not complete the login in time.
+	ErrDeviceCodeExpired = errors.New("the device code expired before the login was completed")
+
+	// ErrDeviceAccessDenied is returned when the user denied the login request.
+	ErrDeviceAccessDenied = errors.New("the login request was denied")
+)
+
+// deviceSleep waits between polls. It is replaced in tests.
+var deviceSleep = sleepContext
+
+// DeviceLogin logs in via the OAuth2 device authorization grant (RFC 8628).
+//
+// The user is asked to visit a verification URL on any device and enter a code,
+// while the token endpoint is polled until the login is approved, denied, or
+// expires. This is useful where a local browser or redirect server is not available.
+func DeviceLogin(ctx context.Context, cnf *config.Config, opts *DeviceLoginOptions) (*oauth2.Token, error) {
+	if cnf.API.OAuth2DeviceAuthURL == "" || cnf.API.OAuth2TokenURL == "" {
+		return nil, errors.New("the OAuth2 device authorization and token URLs are not configured")
+	}
+	if opts.Stderr == nil {
+		opts.Stderr = io.Discard
+	}
+
+	oauthConf := oauthConfig(cnf)
+	oauthConf.Endpoint.DeviceAuthURL = cnf.API.OAuth2DeviceAuthURL
+
+	da, err := oauthConf.DeviceAuth(oauthContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("could not start device login: %w", err)
+	}
+
+	fmt.Fprintln(opts.Stderr, "Please open the following URL in a browser on any device:")
+	fmt.Fprintln(opts.Stderr, da.VerificationURI)
+	fmt.Fprintln(opts.Stderr)
+	fmt.Fprintln(opts.Stderr, "Then enter the code:", da.UserCode)
+	if da.VerificationURIComplete != "" {
+		fmt.Fprintln(opts.Stderr)
+		fmt.Fprintln(opts.Stderr, "Alternatively, open this URL which includes the code:")
+		fmt.Fprintln(opts.Stderr, da.VerificationURIComplete)
+	}
+	fmt.Fprintln(opts.Stderr)
+	fmt.Fprintln(opts.Stderr, "Waiting for login...")
+
+	tok, err := pollDeviceToken(ctx, oauthConf, da)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveSessionToken(cnf, tok); err != nil {
+		return nil, err
+	}
+
+	return tok, nil
+}
+
+// pollDeviceToken polls the token endpoint until the device authorization is
+// complete, following the interval and "slow_down" rules of RFC 8628, section 3.5.
+func pollDeviceToken(
+	ctx context.Context, oauthConf *oauth2.Config, da *oauth2.DeviceAuthResponse,
+) (*oauth2.Token, error) {
+	interval := time.Duration(da.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
+	form.Set("device_code", da.DeviceCode)
+	if oauthConf.ClientID != "" {
+		form.Set("client_id", oauthConf.ClientID)
+	}
+
+	for {
+		if err := deviceSleep(ctx, interval); err != nil {
+			return nil, err
+		}
+		if !da.Expiry.IsZero() && time.Now().After(da.Expiry) {
+			return nil, ErrDeviceCodeExpired
+		}
+
+		tok, err := requestToken(oauthContext(ctx), oauthConf.Endpoint.TokenURL, form)
+		if err == nil {
+			return tok, nil
+		}
+
+		var tokErr *tokenError
+		if !errors.As(err, &tokErr) {
+			return nil, fmt.Errorf("could not complete device login: %w", err)
+		}
+		switch tokErr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "expired_token":
+			return nil, ErrDeviceCodeExpired
+		case "access_denied":
+			return nil, ErrDeviceAccessDenied
+		default:
+			return nil, fmt.Errorf("could not complete device login: %w", err)
+		}
+	}
+}
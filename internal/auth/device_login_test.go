@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+func TestDeviceLogin(t *testing.T) {
+	cases := []struct {
+		name       string
+		deny       bool
+		verifyAt   int // The sleep after which the user visits the verification URL.
+		wantErr    error
+		wantSleeps []time.Duration
+	}{
+		{name: "approved", verifyAt: 3, wantSleeps: []time.Duration{time.Second, 6 * time.Second, 6 * time.Second}},
+		{name: "approved before the first poll", verifyAt: 1, wantSleeps: []time.Duration{time.Second, 6 * time.Second}},
+		{name: "denied", deny: true, verifyAt: 3, wantErr: ErrDeviceAccessDenied,
+			wantSleeps: []time.Duration{time.Second, 6 * time.Second, 6 * time.Second}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			authServer := mockapi.NewAuthServer(t)
+			defer authServer.Close()
+
+			cnf := testConfig(t, authServer.URL)
+			cnf.API.OAuth2DeviceAuthURL = authServer.URL + "/oauth2/device"
+
+			stderr := &strings.Builder{}
+
+			// Imitate the user visiting the verification URL.
+			var sleeps []time.Duration
+			origSleep := deviceSleep
+			defer func() { deviceSleep = origSleep }()
+			deviceSleep = func(_ context.Context, d time.Duration) error {
+				sleeps = append(sleeps, d)
+				if len(sleeps) == c.verifyAt {
+					verifyURL := findVerificationURL(t, stderr.String())
+					if c.deny {
+						verifyURL += "&deny=1"
+					}
+					resp, err := http.Get(verifyURL) //nolint:gosec // test URL
+					require.NoError(t, err)
+					resp.Body.Close()
+					assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+				}
+				return nil
+			}
+
+			tok, err := DeviceLogin(context.Background(), cnf, &DeviceLoginOptions{Stderr: stderr})
+			assert.Equal(t, c.wantSleeps, sleeps)
+			if c.wantErr != nil {
+				assert.ErrorIs(t, err, c.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "access-token-1", tok.AccessToken)
+
+			store, err := NewSessionStore(cnf)
+			require.NoError(t, err)
+			saved, err := store.Load()
+			require.NoError(t, err)
+			assert.Equal(t, tok.RefreshToken, saved.RefreshToken)
+		})
+	}
+}
+
+func findVerificationURL(t *testing.T, output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if u, err := url.Parse(line); err == nil && u.Query().Get("user_code") != "" {
+			return line
+		}
+	}
+	t.Fatalf("verification URL not found in output: %s", output)
+	return ""
+}
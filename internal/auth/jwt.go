@@ -9,31 +9,44 @@ import (
 	"time"
 )
 
-// unsafeGetJWTExpiry parses a JWT without verifying its signature and returns its expiry time.
+// jwtClaims are the JWT claims read by the CLI.
+type jwtClaims struct {
+	ExpiresAt *int64 `json:"exp,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+}
+
+// unsafeGetJWTClaims parses a JWT without verifying its signature and returns its claims.
 // WARNING: This is intentionally unsafe and must not be used for trust decisions.
-func unsafeGetJWTExpiry(token string) (time.Time, error) {
+func unsafeGetJWTClaims(token string) (*jwtClaims, error) {
 	if token == "" {
-		return time.Time{}, errors.New("jwt: empty token")
+		return nil, errors.New("jwt: empty token")
 	}
 	parts := strings.Split(token, ".")
 	if len(parts) < 2 {
-		return time.Time{}, fmt.Errorf("jwt: malformed token, expected 3 parts, got %d", len(parts))
+		return nil, fmt.Errorf("jwt: malformed token, expected 3 parts, got %d", len(parts))
 	}
 	payloadSeg := parts[1]
 
 	// Base64 URL decode without padding as per RFC 7515.
 	payloadBytes, err := base64.RawURLEncoding.DecodeString(payloadSeg)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("jwt: decode payload: %w", err)
+		return nil, fmt.Errorf("jwt: decode payload: %w", err)
 	}
 
-	var claims struct {
-		ExpiresAt *int64 `json:"exp,omitempty"`
-	}
+	var claims jwtClaims
 	if err := json.Unmarshal(payloadBytes, &claims); err != nil {
-		return time.Time{}, fmt.Errorf("jwt: unmarshal claims: %w", err)
+		return nil, fmt.Errorf("jwt: unmarshal claims: %w", err)
 	}
+	return &claims, nil
+}
 
+// unsafeGetJWTExpiry parses a JWT without verifying its signature and returns its expiry time.
+// WARNING: This is intentionally unsafe and must not be used for trust decisions.
+func unsafeGetJWTExpiry(token string) (time.Time, error) {
+	claims, err := unsafeGetJWTClaims(token)
+	if err != nil {
+		return time.Time{}, err
+	}
 	if claims.ExpiresAt == nil {
 		return time.Time{}, errors.New("jwt: no expiry time found")
 	}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/httpclient"
+)
+
+var errNoRefreshToken = errors.New("no refresh token available")
+
+// sessionTokenSource is a token source that reads tokens from a SessionStore,
+// and refreshes them natively, without calling the legacy CLI.
+type sessionTokenSource struct {
+	ctx         context.Context
+	store       *SessionStore
+	oauthConfig *oauth2.Config
+
+	cached *oauth2.Token
+	// invalidated is an access token which was rejected by the API, and which
+	// must not be used again even if it has not expired.
+	invalidated string
+	mu          sync.Mutex
+}
+
+// NewSessionTokenSource creates a token source backed by the configured session.
+// It returns an error wrapping ErrNoSession if the session cannot be read.
+func NewSessionTokenSource(ctx context.Context, cnf *config.Config) (oauth2.TokenSource, error) {
+	store, err := NewSessionStore(cnf)
+	if err != nil {
+		return nil, err
+	}
+	tok, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("could not load session %s: %w", store.ID, err)
+	}
+	if tok.RefreshToken == "" && !tok.Valid() {
+		return nil, fmt.Errorf("could not load session %s: %w", store.ID, ErrNoSession)
+	}
+
+	return &sessionTokenSource{
+		ctx:         ctx,
+		store:       store,
+		oauthConfig: oauthConfig(cnf),
+		cached:      tok,
+	}, nil
+}
+
+// oauthConfig returns the OAuth2 client configuration for the CLI.
+func oauthConfig(cnf *config.Config) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID: cnf.API.OAuth2ClientID,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   cnf.API.OAuth2AuthorizeURL,
+			TokenURL:  cnf.API.OAuth2TokenURL,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+}
+
+// oauthContext returns a context carrying the HTTP client used for OAuth2 token requests.
+func oauthContext(ctx context.Context) context.Context {
+	baseRT := http.DefaultTransport
+	if rt, ok := TransportFromContext(ctx); ok {
+		baseRT = rt
+	} else if cnf, ok := config.MaybeFromContext(ctx); ok {
+		baseRT = httpclient.NewTransport(cnf)
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: baseRT})
+}
+
+func (ts *sessionTokenSource) Token() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.usable(ts.cached) {
+		return ts.cached, nil
+	}
+
+	unlock, err := ts.store.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Another process may have refreshed the token while this one was waiting.
+	if tok, err := ts.store.Load(); err == nil && ts.usable(tok) {
+		ts.cached = tok
+		return tok, nil
+	}
+
+	if err := ts.unsafeRefreshToken(); err != nil {
+		return nil, err
+	}
+
+	return ts.cached, nil
+}
+
+// usable checks if a token is valid and has not been invalidated.
+func (ts *sessionTokenSource) usable(tok *oauth2.Token) bool {
+	return tok != nil && tok.Valid() && tok.AccessToken != ts.invalidated
+}
+
+func (ts *sessionTokenSource) refreshToken() error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	unlock, err := ts.store.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return ts.unsafeRefreshToken()
+}
+
+// unsafeRefreshToken exchanges the refresh token for a new access token, and
+// saves it. The caller must hold both the mutex and the session lock.
+func (ts *sessionTokenSource) unsafeRefreshToken() error {
+	var refreshToken string
+	if ts.cached != nil {
+		refreshToken = ts.cached.RefreshToken
+	}
+	if stored, err := ts.store.Load(); err == nil && stored.RefreshToken != "" {
+		refreshToken = stored.RefreshToken
+	}
+	if refreshToken == "" {
+		return fmt.Errorf("cannot refresh token: %w", errNoRefreshToken)
+	}
+
+	tok, err := ts.oauthConfig.TokenSource(oauthContext(ts.ctx), &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		return fmt.Errorf("cannot refresh token: %w", err)
+	}
+	if err := ts.store.Save(tok); err != nil {
+		return fmt.Errorf("cannot save refreshed token: %w", err)
+	}
+	ts.cached = tok
+
+	return nil
+}
+
+func (ts *sessionTokenSource) invalidateToken() error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.cached != nil {
+		ts.invalidated = ts.cached.AccessToken
+		ts.cached = nil
+	}
+
+	return nil
+}
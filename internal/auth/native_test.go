@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/pkg/mockapi"
+)
+
+func testConfig(t *testing.T, authURL string) *config.Config {
+	t.Helper()
+	cnf := &config.Config{}
+	cnf.Application.Name = "Test CLI"
+	cnf.Application.EnvPrefix = "TEST_CLI_"
+	cnf.Application.Slug = "test-cli"
+	cnf.Application.WritableUserDir = ".test-cli"
+	cnf.Application.UserStateFile = "state.json"
+	cnf.API.SessionID = "default"
+	cnf.API.OAuth2ClientID = "test-cli"
+	cnf.API.OAuth2TokenURL = authURL + "/oauth2/token"
+	t.Setenv(cnf.Application.EnvPrefix+"HOME", t.TempDir())
+	return cnf
+}
+
+func TestSessionTokenSource(t *testing.T) {
+	authServer := mockapi.NewAuthServer(t)
+	defer authServer.Close()
+
+	cnf := testConfig(t, authServer.URL)
+	ctx := context.Background()
+
+	_, err := NewSessionTokenSource(ctx, cnf)
+	assert.ErrorIs(t, err, ErrNoSession)
+
+	store, err := NewSessionStore(cnf)
+	require.NoError(t, err)
+	assert.Equal(t, "cli-default", store.ID)
+
+	// Write a legacy-format session file with an expired access token and an extra key.
+	require.NoError(t, os.MkdirAll(store.Dir, 0o700))
+	sessionFile := filepath.Join(store.Dir, "sess-cli-default.json")
+	require.NoError(t, os.WriteFile(sessionFile, []byte(`{
+		"accessToken": "expired-token",
+		"tokenType": "bearer",
+		"expires": 1,
+		"refreshToken": "refresh-token-1",
+		"other": "preserved"
+	}`), 0o600))
+
+	ts, err := NewSessionTokenSource(ctx, cnf)
+	require.NoError(t, err)
+
+	tok, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access-token-1", tok.AccessToken)
+	assert.True(t, tok.Expiry.After(time.Now()))
+
+	b, err := os.ReadFile(sessionFile)
+	require.NoError(t, err)
+	var saved map[string]any
+	require.NoError(t, json.Unmarshal(b, &saved))
+	assert.Equal(t, "access-token-1", saved["accessToken"])
+	assert.Equal(t, "refresh-token-1", saved["refreshToken"])
+	assert.Equal(t, "preserved", saved["other"])
+
+	// An invalidated token is not reused, even if it has not expired.
+	r, ok := ts.(refresher)
+	require.True(t, ok)
+	require.NoError(t, r.invalidateToken())
+	require.NoError(t, os.WriteFile(sessionFile,
+		[]byte(`{"accessToken": "access-token-1", "expires": 9999999999}`), 0o600))
+	_, err = ts.Token()
+	assert.ErrorContains(t, err, "no refresh token available")
+}
@@ -0,0 +1,250 @@
+package auth
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+	"golang.org/x/oauth2"
+
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/file"
+)
+
+// ErrNoSession is returned when a session does not exist or contains no usable tokens.
+var ErrNoSession = errors.New("no session found")
+
+// SessionStore reads and writes the OAuth2 tokens of an authentication session.
+//
+// It uses the same files as the legacy CLI's session storage, so that tokens
+// obtained or refreshed by either program are visible to the other.
+//
+// If a CredentialStore is configured, the refresh token is saved there instead
+// of in the session file, which then only contains the access token. A refresh
+// token found in the session file (e.g. written by the legacy CLI) is still
+// used, and moved to the credential store on the next save.
+type SessionStore struct {
+	ID  string // The session ID, e.g. "cli-default".
+	Dir string // The directory containing the session file.
+
+	// credentials returns the credential store, or nil. It is only called
+	// when needed, as probing the OS keyring can be slow.
+	credentials func() (CredentialStore, error)
+}
+
+// sessionData represents the token keys written by the legacy CLI.
+type sessionData struct {
+	AccessToken  string `json:"accessToken,omitempty"`
+	TokenType    string `json:"tokenType,omitempty"`
+	Expires      int64  `json:"expires,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
+var sessionIDDisallowedChars = regexp.MustCompile(`[^\w\-]+`)
+
+// NewSessionStore returns the session store for the configured session ID.
+func NewSessionStore(cnf *config.Config) (*SessionStore, error) {
+	return namedSessionStore(cnf, currentSessionID(cnf))
+}
+
+// namedSessionStore returns the store for a named login session, using the
+// configured credential store for refresh tokens.
+func namedSessionStore(cnf *config.Config, sessionID string) (*SessionStore, error) {
+	writableDir, err := cnf.WritableUserDir()
+	if err != nil {
+		return nil, err
+	}
+	store := newSessionStore(writableDir, sessionStorePrefix+sessionID)
+	store.credentials = sync.OnceValues(func() (CredentialStore, error) {
+		return NewCredentialStore(cnf)
+	})
+
+	return store, nil
+}
+
+// newSessionStore returns a session store for an ID, inside a writable user directory.
+func newSessionStore(writableDir, id string) *SessionStore {
+	id = sessionIDDisallowedChars.ReplaceAllString(id, "-")
+	return &SessionStore{
+		ID:  id,
+		Dir: filepath.Join(writableDir, ".session", "sess-"+id),
+	}
+}
+
+func (s *SessionStore) path() string {
+	return filepath.Join(s.Dir, "sess-"+s.ID+".json")
+}
+
+// Lock acquires an exclusive lock on the session, shared across processes.
+// The returned function releases the lock.
+func (s *SessionStore) Lock() (unlock func(), err error) {
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return nil, err
+	}
+	fileLock := flock.New(filepath.Join(s.Dir, ".lock"))
+	if err := fileLock.Lock(); err != nil {
+		return nil, fmt.Errorf("could not acquire session lock: %w", err)
+	}
+	return func() { _ = fileLock.Unlock() }, nil
+}
+
+// Load reads the session's token. It returns ErrNoSession if there is no usable token.
+func (s *SessionStore) Load() (*oauth2.Token, error) {
+	b, err := os.ReadFile(s.path())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNoSession
+		}
+		return nil, err
+	}
+	var d sessionData
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, fmt.Errorf("could not parse session file %s: %w", s.path(), err)
+	}
+	if d.RefreshToken == "" {
+		credentials, err := s.credentialStore()
+		if err != nil {
+			return nil, err
+		}
+		if credentials != nil {
+			refreshToken, err := credentials.Get(s.ID)
+			if err != nil && !errors.Is(err, ErrCredentialNotFound) {
+				return nil, fmt.Errorf("could not read refresh token from %s: %w", credentials.Name(), err)
+			}
+			d.RefreshToken = refreshToken
+		}
+	}
+	if d.AccessToken == "" && d.RefreshToken == "" {
+		return nil, ErrNoSession
+	}
+
+	tok := &oauth2.Token{
+		AccessToken:  d.AccessToken,
+		TokenType:    d.TokenType,
+		RefreshToken: d.RefreshToken,
+	}
+	if d.Expires > 0 {
+		tok.Expiry = time.Unix(d.Expires, 0)
+	} else if d.AccessToken != "" {
+		// Without a recorded expiry, a token that cannot be parsed is treated as expired.
+		expiry, err := unsafeGetJWTExpiry(d.AccessToken)
+		if err != nil {
+			expiry = time.Unix(1, 0)
+		}
+		tok.Expiry = expiry
+	}
+
+	return tok, nil
+}
+
+// CacheKey returns a key identifying the user of the session, for data
+// cached per user, such as API responses. It changes when another user logs
+// in to the session, including through the legacy CLI. It is empty if there
+// is no session.
+func (s *SessionStore) CacheKey() string {
+	tok, err := s.Load()
+	if err != nil {
+		return ""
+	}
+	if claims, err := unsafeGetJWTClaims(tok.AccessToken); err == nil && claims.Subject != "" {
+		return "user-" + claims.Subject
+	}
+	// Without a user ID, the refresh token identifies the login.
+	secret := cmp.Or(tok.RefreshToken, tok.AccessToken)
+	h := sha256.Sum256([]byte(secret))
+	return "login-" + hex.EncodeToString(h[:8])
+}
+
+// Save writes a token to the session, preserving any other keys in the file.
+func (s *SessionStore) Save(tok *oauth2.Token) error {
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return err
+	}
+
+	existing := make(map[string]any)
+	if b, err := os.ReadFile(s.path()); err == nil {
+		_ = json.Unmarshal(b, &existing)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	d := sessionData{
+		AccessToken:  tok.AccessToken,
+		TokenType:    tok.TokenType,
+		RefreshToken: tok.RefreshToken,
+	}
+	if !tok.Expiry.IsZero() {
+		d.Expires = tok.Expiry.Unix()
+	}
+	existing["accessToken"] = d.AccessToken
+	existing["tokenType"] = d.TokenType
+	existing["expires"] = d.Expires
+
+	// The refresh token must not be kept in the session file if there is a credential store.
+	credentials, err := s.credentialStore()
+	if err != nil {
+		return err
+	}
+	if d.RefreshToken != "" && credentials == nil {
+		existing["refreshToken"] = d.RefreshToken
+	} else {
+		delete(existing, "refreshToken")
+	}
+	if err := saveRefreshToken(credentials, s.ID, d.RefreshToken); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(existing)
+	if err != nil {
+		return err
+	}
+
+	return file.Write(s.path(), b, 0o600)
+}
+
+// credentialStore returns the configured credential store, or nil.
+func (s *SessionStore) credentialStore() (CredentialStore, error) {
+	if s.credentials == nil {
+		return nil, nil
+	}
+	return s.credentials()
+}
+
+// saveRefreshToken saves or deletes a refresh token in a credential store, if any.
+func saveRefreshToken(credentials CredentialStore, key, refreshToken string) error {
+	if credentials == nil {
+		return nil
+	}
+	var err error
+	if refreshToken != "" {
+		err = credentials.Set(key, refreshToken)
+	} else {
+		err = credentials.Delete(key)
+	}
+	if err != nil {
+		return fmt.Errorf("could not save refresh token to %s: %w", credentials.Name(), err)
+	}
+	return nil
+}
+
+// Delete removes the session's tokens.
+func (s *SessionStore) Delete() error {
+	if err := os.Remove(s.path()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	credentials, err := s.credentialStore()
+	if err != nil || credentials == nil {
+		return err
+	}
+	return credentials.Delete(s.ID)
+}
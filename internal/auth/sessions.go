@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/platformsh/cli/internal/api"
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/state"
+)
+
+// DefaultSessionID is the ID of the session used when no other is selected.
+const DefaultSessionID = "default"
+
+// sessionStorePrefix prefixes the session IDs of named login sessions, as
+// opposed to other sessions such as those caching API token exchanges.
+const sessionStorePrefix = "cli-"
+
+var validSessionID = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ValidateSessionID checks that a session ID is safe to use.
+func ValidateSessionID(id string) error {
+	if !validSessionID.MatchString(id) {
+		return fmt.Errorf("invalid session ID %q: only letters, numbers, hyphens and underscores are allowed", id)
+	}
+	return nil
+}
+
+// ApplySessionID selects the session for this invocation, by setting it in the config.
+//
+// The session is taken from the override (e.g. a --session flag) if not empty,
+// then the {ENV_PREFIX}SESSION_ID environment variable, then the active session
+// recorded in the state, falling back to the configured session ID.
+func ApplySessionID(cnf *config.Config, override string) error {
+	id := override
+	if id == "" {
+		id = os.Getenv(cnf.Application.EnvPrefix + "SESSION_ID")
+	}
+	if id == "" {
+		s, err := state.Load(cnf)
+		if err != nil {
+			return err
+		}
+		id = s.Auth.ActiveSessionID
+	}
+	if id == "" {
+		return nil
+	}
+	if err := ValidateSessionID(id); err != nil {
+		return err
+	}
+	cnf.API.SessionID = id
+	return nil
+}
+
+// SessionInfo describes a named login session.
+type SessionInfo struct {
+	ID       string
+	Active   bool
+	LoggedIn bool
+}
+
+// ListSessions lists the named login sessions that exist on disk, along with the
+// default and currently selected sessions.
+func ListSessions(cnf *config.Config) ([]SessionInfo, error) {
+	writableDir, err := cnf.WritableUserDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(writableDir, ".session"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	ids := []string{DefaultSessionID}
+	if id := currentSessionID(cnf); id != DefaultSessionID {
+		ids = append(ids, id)
+	}
+	for _, e := range entries {
+		id, ok := strings.CutPrefix(e.Name(), "sess-"+sessionStorePrefix)
+		if e.IsDir() && ok && validSessionID.MatchString(id) && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	sessions := make([]SessionInfo, 0, len(ids))
+	for _, id := range ids {
+		store, err := namedSessionStore(cnf, id)
+		if err != nil {
+			return nil, err
+		}
+		_, loadErr := store.Load()
+		if loadErr != nil && !errors.Is(loadErr, ErrNoSession) {
+			return nil, loadErr
+		}
+		sessions = append(sessions, SessionInfo{
+			ID:       id,
+			Active:   id == currentSessionID(cnf),
+			LoggedIn: loadErr == nil,
+		})
+	}
+	return sessions, nil
+}
+
+// SessionExists checks whether a named session exists on disk.
+func SessionExists(cnf *config.Config, id string) (bool, error) {
+	writableDir, err := cnf.WritableUserDir()
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(newSessionStore(writableDir, sessionStorePrefix+id).Dir)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+// CreateSession creates an empty named session, ready for logging in.
+func CreateSession(cnf *config.Config, id string) error {
+	if err := ValidateSessionID(id); err != nil {
+		return err
+	}
+	writableDir, err := cnf.WritableUserDir()
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(newSessionStore(writableDir, sessionStorePrefix+id).Dir, 0o700)
+}
+
+// SwitchSession records a session as active in the state, for future invocations.
+func SwitchSession(cnf *config.Config, id string) error {
+	if err := ValidateSessionID(id); err != nil {
+		return err
+	}
+	s, err := state.Load(cnf)
+	if err != nil {
+		return err
+	}
+	s.Auth.ActiveSessionID = id
+	return state.Save(s, cnf)
+}
+
+// DeleteSession deletes a named session, including its tokens and any other
+// files such as SSH certificates. If it was the active session, the active
+// session is reset to the default.
+func DeleteSession(cnf *config.Config, id string) error {
+	if err := ValidateSessionID(id); err != nil {
+		return err
+	}
+	store, err := namedSessionStore(cnf, id)
+	if err != nil {
+		return err
+	}
+	unlock, err := store.Lock()
+	if err != nil {
+		return err
+	}
+	err = store.Delete()
+	if err == nil {
+		err = os.RemoveAll(store.Dir)
+	}
+	if err == nil {
+		err = api.ClearCache(cnf, id)
+	}
+	unlock()
+	if err != nil {
+		return fmt.Errorf("could not delete session: %w", err)
+	}
+
+	s, err := state.Load(cnf)
+	if err != nil {
+		return err
+	}
+	if s.Auth.ActiveSessionID == id {
+		s.Auth.ActiveSessionID = ""
+		return state.Save(s, cnf)
+	}
+	return nil
+}
+
+func currentSessionID(cnf *config.Config) string {
+	if cnf.API.SessionID == "" {
+		return DefaultSessionID
+	}
+	return cnf.API.SessionID
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestSessions(t *testing.T) {
+	cnf := testConfig(t, "http://127.0.0.1")
+
+	sessions, err := ListSessions(cnf)
+	require.NoError(t, err)
+	assert.Equal(t, []SessionInfo{{ID: "default", Active: true}}, sessions)
+
+	require.NoError(t, CreateSession(cnf, "work"))
+	assert.Error(t, CreateSession(cnf, "../work"))
+	exists, err := SessionExists(cnf, "work")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// The active session is selected from the state, the environment, or an override.
+	require.NoError(t, SwitchSession(cnf, "work"))
+	require.NoError(t, ApplySessionID(cnf, ""))
+	assert.Equal(t, "work", cnf.API.SessionID)
+
+	t.Setenv(cnf.Application.EnvPrefix+"SESSION_ID", "from-env")
+	require.NoError(t, ApplySessionID(cnf, ""))
+	assert.Equal(t, "from-env", cnf.API.SessionID)
+
+	require.NoError(t, ApplySessionID(cnf, "default"))
+	assert.Equal(t, "default", cnf.API.SessionID)
+	assert.Error(t, ApplySessionID(cnf, "in/valid"))
+
+	// Each session has isolated token storage.
+	require.NoError(t, saveSessionToken(cnf, &oauth2.Token{
+		AccessToken: "default-token",
+		Expiry:      time.Now().Add(time.Hour),
+	}))
+	require.NoError(t, ApplySessionID(cnf, "work"))
+	store, err := NewSessionStore(cnf)
+	require.NoError(t, err)
+	_, err = store.Load()
+	assert.ErrorIs(t, err, ErrNoSession)
+
+	sessions, err = ListSessions(cnf)
+	require.NoError(t, err)
+	assert.Equal(t, []SessionInfo{
+		{ID: "default", LoggedIn: true},
+		{ID: "work", Active: true},
+	}, sessions)
+
+	// Deleting the active session resets the state.
+	require.NoError(t, DeleteSession(cnf, "work"))
+	exists, err = SessionExists(cnf, "work")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	t.Setenv(cnf.Application.EnvPrefix+"SESSION_ID", "")
+	cnf.API.SessionID = "default"
+	require.NoError(t, ApplySessionID(cnf, ""))
+	assert.Equal(t, "default", cnf.API.SessionID)
+}
+
+func TestSessionStore_CacheKey(t *testing.T) {
+	cnf := testConfig(t, "http://127.0.0.1")
+	store, err := NewSessionStore(cnf)
+	require.NoError(t, err)
+	assert.Equal(t, "", store.CacheKey())
+
+	// The legacy CLI may log in to the session as another user.
+	writeSession := func(accessToken, refreshToken string) {
+		require.NoError(t, os.MkdirAll(store.Dir, 0o700))
+		b, err := json.Marshal(map[string]any{"accessToken": accessToken, "refreshToken": refreshToken})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(store.path(), b, 0o600))
+	}
+	jwt := func(claims string) string {
+		return "e30." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".sig"
+	}
+
+	writeSession(jwt(`{"sub":"user-1","exp":1}`), "refresh-1")
+	assert.Equal(t, "user-user-1", store.CacheKey())
+	writeSession(jwt(`{"sub":"user-1","exp":2}`), "refresh-2")
+	assert.Equal(t, "user-user-1", store.CacheKey())
+	writeSession(jwt(`{"sub":"user-2","exp":1}`), "refresh-1")
+	assert.Equal(t, "user-user-2", store.CacheKey())
+
+	// Without a user ID, the key changes with the login.
+	writeSession("opaque-token", "refresh-1")
+	key := store.CacheKey()
+	assert.True(t, strings.HasPrefix(key, "login-"), key)
+	writeSession("opaque-token", "refresh-2")
+	assert.NotEqual(t, key, store.CacheKey())
+}
@@ -3,19 +3,42 @@ package auth
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os/exec"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
 )
 
 type refresher interface {
+	oauth2.TokenSource
 	refreshToken() error
 	invalidateToken() error
 }
 
+// ErrReauthenticationRequired is returned when the access token cannot be
+// refreshed without the user logging in again.
+var ErrReauthenticationRequired = errors.New("re-authentication required")
+
+const (
+	// DefaultRefreshAhead is how long before its expiry an access token is refreshed.
+	DefaultRefreshAhead = 30 * time.Second
+
+	defaultMaxRefreshAttempts = 3
+	defaultRefreshBackoff     = 500 * time.Millisecond
+)
+
 // Transport is an HTTP RoundTripper similar to golang.org/x/oauth2.Transport.
 // It injects Authorization headers using a savingSource and, on a 401 response,
 // clears the cached token and retries the request once.
+//
+// Tokens are refreshed ahead of their expiry. Concurrent refreshes are
+// coalesced, so that only one refresh happens at a time, and failed refreshes
+// are retried with an exponential backoff.
 type Transport struct {
 	// base is the underlying oauth2.Transport that adds the Authorization header.
 	base http.RoundTripper
@@ -25,6 +48,16 @@ type Transport struct {
 	refresher refresher
 
 	LogFunc func(msg string, args ...any)
+
+	// RefreshAhead is how long before expiry to refresh the token (default: DefaultRefreshAhead).
+	RefreshAhead time.Duration
+	// MaxRefreshAttempts is the number of times a refresh is attempted (default: 3).
+	MaxRefreshAttempts int
+	// RefreshBackoff is the delay after the first failed refresh attempt, which doubles after each attempt.
+	RefreshBackoff time.Duration
+
+	group singleflight.Group
+	sleep func(ctx context.Context, d time.Duration) error
 }
 
 // RoundTrip adds Authorization via the underlying oauth2.Transport. If the
@@ -32,21 +65,163 @@ type Transport struct {
 func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Body = wrapReader(req.Body)
 
+	tok, err := t.freshToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := t.base.RoundTrip(req)
 
 	// Retry on 401
 	if resp != nil && resp.StatusCode == http.StatusUnauthorized {
 		_ = t.log("The access token needs to be refreshed. Retrying request.")
-		if err := t.refresher.invalidateToken(); err != nil {
-			return nil, fmt.Errorf("failed to invalidate token: %w", err)
-		}
 		flushReader(resp.Body)
+		if err := t.replaceRejectedToken(req.Context(), tok.AccessToken); err != nil {
+			return nil, err
+		}
 		resp, err = t.base.RoundTrip(req)
 	}
 
 	return resp, err
 }
 
+// freshToken returns the current token, refreshing it first if it expires soon.
+func (t *Transport) freshToken(ctx context.Context) (*oauth2.Token, error) {
+	tok, err := t.refresher.Token()
+	if err != nil {
+		if isReauthenticationError(err) {
+			return nil, fmt.Errorf("%w: %w", ErrReauthenticationRequired, err)
+		}
+		if err := t.refresh(ctx, nil); err != nil {
+			return nil, err
+		}
+		return t.refresher.Token()
+	}
+	if !t.expiresSoon(tok) {
+		return tok, nil
+	}
+
+	_ = t.log("The access token expires soon. Refreshing it.")
+	if err := t.refresh(ctx, func(current *oauth2.Token) bool { return t.expiresSoon(current) }); err != nil {
+		// The token can still be used until it expires.
+		if tok.Valid() {
+			_ = t.log("Failed to refresh the access token: %s", err)
+			return tok, nil
+		}
+		return nil, err
+	}
+	return t.refresher.Token()
+}
+
+// replaceRejectedToken invalidates and refreshes a token which the API rejected,
+// unless another request has already replaced it.
+func (t *Transport) replaceRejectedToken(ctx context.Context, rejected string) error {
+	return t.refresh(ctx, func(current *oauth2.Token) bool {
+		if current.AccessToken != rejected {
+			return false
+		}
+		if err := t.refresher.invalidateToken(); err != nil {
+			_ = t.log("Failed to invalidate token: %s", err)
+		}
+		return true
+	})
+}
+
+// refresh refreshes the token, sharing a single refresh between concurrent callers.
+// If needed is not nil, it is called with the current token before refreshing,
+// and the refresh is skipped if it returns false.
+func (t *Transport) refresh(ctx context.Context, needed func(current *oauth2.Token) bool) error {
+	_, err, _ := t.group.Do("refresh", func() (any, error) {
+		if needed != nil {
+			if current, err := t.refresher.Token(); err == nil && !needed(current) {
+				return nil, nil
+			}
+		}
+		return nil, t.refreshWithRetry(ctx)
+	})
+	return err
+}
+
+// refreshWithRetry refreshes the token, retrying temporary failures with an exponential backoff.
+func (t *Transport) refreshWithRetry(ctx context.Context) error {
+	attempts := t.MaxRefreshAttempts
+	if attempts <= 0 {
+		attempts = defaultMaxRefreshAttempts
+	}
+	backoff := t.RefreshBackoff
+	if backoff <= 0 {
+		backoff = defaultRefreshBackoff
+	}
+	sleep := t.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			_ = t.log("Failed to refresh the access token (attempt %d/%d): %s", attempt-1, attempts, err)
+			if err := sleep(ctx, backoff); err != nil {
+				return err
+			}
+			backoff *= 2
+		}
+		if err = t.refresher.refreshToken(); err == nil {
+			return nil
+		}
+		if isReauthenticationError(err) {
+			return fmt.Errorf("%w: %w", ErrReauthenticationRequired, err)
+		}
+	}
+	return err
+}
+
+func (t *Transport) expiresSoon(tok *oauth2.Token) bool {
+	expiry := tok.Expiry
+	if expiry.IsZero() {
+		var err error
+		if expiry, err = unsafeGetJWTExpiry(tok.AccessToken); err != nil {
+			return false
+		}
+	}
+	refreshAhead := t.RefreshAhead
+	if refreshAhead <= 0 {
+		refreshAhead = DefaultRefreshAhead
+	}
+	return time.Until(expiry) < refreshAhead
+}
+
+// isReauthenticationError checks whether a token error is permanent, i.e. it
+// cannot be resolved by retrying, and the user needs to log in again.
+func isReauthenticationError(err error) bool {
+	if errors.Is(err, ErrReauthenticationRequired) || errors.Is(err, ErrNoSession) ||
+		errors.Is(err, errNoRefreshToken) {
+		return true
+	}
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		return retrieveErr.Response != nil && retrieveErr.Response.StatusCode < http.StatusInternalServerError
+	}
+	var tokErr *tokenError
+	if errors.As(err, &tokErr) {
+		return tokErr.Code != "server_error" && tokErr.Code != "temporarily_unavailable"
+	}
+	// The legacy CLI exits with an error if it cannot refresh the token.
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (t *Transport) log(msg string, args ...any) error {
 	if t.LogFunc == nil {
 		return nil
@@ -2,9 +2,13 @@ package auth
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -116,3 +120,118 @@ func TestTransport_RoundTrip_RetryOn401(t *testing.T) {
 	// Assert the response codes (401 first and then a 200)
 	assert.Equal(t, []int{http.StatusUnauthorized, http.StatusOK}, responseCodes)
 }
+
+// countingRefresher is a thread-safe refresher which counts refreshes, and
+// which can be made to fail.
+type countingRefresher struct {
+	mu        sync.Mutex
+	token     *oauth2.Token
+	refreshes int
+	errs      []error
+}
+
+func (c *countingRefresher) refreshToken() error {
+	time.Sleep(20 * time.Millisecond)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshes++
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return err
+	}
+	c.token = &oauth2.Token{
+		AccessToken: fmt.Sprintf("token-%d", c.refreshes),
+		Expiry:      time.Now().Add(time.Hour),
+	}
+	return nil
+}
+
+func (c *countingRefresher) invalidateToken() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = nil
+	return nil
+}
+
+func (c *countingRefresher) Token() (*oauth2.Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == nil {
+		return nil, errors.New("no token")
+	}
+	return c.token, nil
+}
+
+func newTestTransport(ref *countingRefresher) *Transport {
+	return &Transport{
+		base:      &oauth2.Transport{Source: ref, Base: http.DefaultTransport},
+		refresher: ref,
+	}
+}
+
+func TestTransport_ProactiveRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	// The token is still valid, but expires within the refresh window.
+	ref := &countingRefresher{token: &oauth2.Token{AccessToken: "token-0", Expiry: time.Now().Add(15 * time.Second)}}
+	client := &http.Client{Transport: newTestTransport(ref)}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, ref.refreshes)
+}
+
+func TestTransport_RefreshRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	ref := &countingRefresher{errs: []error{errors.New("network error"), errors.New("network error")}}
+	transport := newTestTransport(ref)
+	var sleeps []time.Duration
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 3, ref.refreshes)
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second}, sleeps)
+}
+
+func TestTransport_ReauthenticationRequired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	ref := &countingRefresher{errs: []error{
+		fmt.Errorf("cannot refresh token: %w", &oauth2.RetrieveError{
+			Response:  &http.Response{StatusCode: http.StatusBadRequest},
+			ErrorCode: "invalid_grant",
+		}),
+	}}
+	client := &http.Client{Transport: newTestTransport(ref)}
+
+	_, err := client.Get(server.URL) //nolint:bodyclose // the request fails
+	assert.ErrorIs(t, err, ErrReauthenticationRequired)
+	assert.Equal(t, 1, ref.refreshes)
+}
@@ -11,6 +11,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/httpclient"
 )
 
 // FetchConfig makes an HTTP request to fetch some config YAML, validates it, and returns decoded versions.
@@ -32,6 +33,7 @@ func FetchConfig(ctx context.Context, url string) (cnfNode *yaml.Node, cnfStruct
 	}
 	if cnf, ok := config.MaybeFromContext(ctx); ok {
 		req.Header.Set("User-Agent", cnf.UserAgent())
+		httpClient = httpclient.NewClient(cnf, 10*time.Second)
 	}
 	resp, err := httpClient.Do(req)
 	if err != nil {
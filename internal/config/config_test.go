@@ -41,6 +41,9 @@ func TestFromYAML(t *testing.T) {
 		assert.Equal(t, cnf.Application.UserConfigDir, cnf.Application.WritableUserDir)
 		assert.Equal(t, "example-cli-tmp", cnf.Application.TempSubDir)
 		assert.Equal(t, "platform", cnf.Service.ProjectConfigFlavor)
+		assert.Equal(t, "default", cnf.API.SessionID)
+		assert.Equal(t, "https://auth.example.com/oauth2/token", cnf.API.OAuth2TokenURL)
+		assert.Equal(t, "https://auth.example.com/oauth2/authorize", cnf.API.OAuth2AuthorizeURL)
 
 		homeDir, err := cnf.HomeDir()
 		require.NoError(t, err)
@@ -22,6 +22,10 @@ type Config struct {
 	Wrapper struct {
 		HomebrewTap string `yaml:"homebrew_tap,omitempty"` // e.g. "platformsh/tap/platformsh-cli"
 		GitHubRepo  string `yaml:"github_repo,omitempty"`  // e.g. "platformsh/cli"
+
+		// Native commands to run with the legacy CLI instead, as names or wildcard patterns, e.g. ["project:list", "variable:*"].
+		// More can be added in the {ENV_PREFIX}LEGACY_COMMANDS environment variable, separated by commas.
+		LegacyCommands []string `yaml:"legacy_commands,omitempty"`
 	} `yaml:"wrapper,omitempty"`
 
 	Application struct {
@@ -40,19 +44,29 @@ type Config struct {
 		CheckInterval int  `validate:"omitempty" yaml:"check_interval,omitempty"` // seconds, defaults to 3600
 	} `validate:"omitempty"`
 
+	// HTTP client settings, each of which can be overridden by an environment variable.
+	HTTP struct {
+		Proxy      string `validate:"omitempty,url" yaml:"proxy,omitempty"`   // e.g. "http://proxy.example.com:3128" - defaults to HTTPS_PROXY etc. ({ENV_PREFIX}PROXY)
+		CABundle   string `validate:"omitempty" yaml:"ca_bundle,omitempty"`   // path to a PEM file of trusted CA certificates ({ENV_PREFIX}CA_BUNDLE)
+		ClientCert string `validate:"omitempty" yaml:"client_cert,omitempty"` // path to a PEM client certificate for mutual TLS, not used by the legacy CLI ({ENV_PREFIX}CLIENT_CERT)
+		ClientKey  string `validate:"omitempty" yaml:"client_key,omitempty"`  // path to the client certificate's key - defaults to ClientCert ({ENV_PREFIX}CLIENT_KEY)
+	} `validate:"omitempty" yaml:"http,omitempty"`
+
 	// Fields only needed by the PHP (legacy) CLI, at least for now.
 	API struct {
 		BaseURL string `validate:"required,url" yaml:"base_url"`            // e.g. "https://api.upsun.com"
 		AuthURL string `validate:"omitempty,url" yaml:"auth_url,omitempty"` // e.g. "https://auth.upsun.com"
 
-		UserAgent string `validate:"omitempty" yaml:"user_agent,omitempty"`       // a template - see UserAgent method
-		SessionID string `validate:"omitempty,ascii" yaml:"session_id,omitempty"` // the ID for the authentication session - defaults to "default"
+		UserAgent       string `validate:"omitempty" yaml:"user_agent,omitempty"`                                       // a template - see UserAgent method
+		SessionID       string `validate:"omitempty,ascii" yaml:"session_id,omitempty"`                                 // the ID for the authentication session - defaults to "default"
+		CredentialStore string `validate:"omitempty,oneof=session keyring file auto" yaml:"credential_store,omitempty"` // where to store refresh tokens - defaults to "session"
 
-		OAuth2ClientID     string `validate:"omitempty" yaml:"oauth2_client_id,omitempty"`                               // e.g. "upsun-cli"
-		OAuth2AuthorizeURL string `validate:"required_without=AuthURL,omitempty,url" yaml:"oauth2_auth_url,omitempty"`   // e.g. "https://auth.upsun.com/oauth2/authorize"
-		OAuth2RevokeURL    string `validate:"required_without=AuthURL,omitempty,url" yaml:"oauth2_revoke_url,omitempty"` // e.g. "https://auth.upsun.com/oauth2/revoke"
-		OAuth2TokenURL     string `validate:"required_without=AuthURL,omitempty,url" yaml:"oauth2_token_url,omitempty"`  // e.g. "https://auth.upsun.com/oauth2/token"
-		CertifierURL       string `validate:"required_without=AuthURL,omitempty,url" yaml:"certifier_url,omitempty"`     // No longer used
+		OAuth2ClientID      string `validate:"omitempty" yaml:"oauth2_client_id,omitempty"`                               // e.g. "upsun-cli"
+		OAuth2AuthorizeURL  string `validate:"required_without=AuthURL,omitempty,url" yaml:"oauth2_auth_url,omitempty"`   // e.g. "https://auth.upsun.com/oauth2/authorize"
+		OAuth2RevokeURL     string `validate:"required_without=AuthURL,omitempty,url" yaml:"oauth2_revoke_url,omitempty"` // e.g. "https://auth.upsun.com/oauth2/revoke"
+		OAuth2TokenURL      string `validate:"required_without=AuthURL,omitempty,url" yaml:"oauth2_token_url,omitempty"`  // e.g. "https://auth.upsun.com/oauth2/token"
+		OAuth2DeviceAuthURL string `validate:"omitempty,url" yaml:"oauth2_device_auth_url,omitempty"`                     // e.g. "https://auth.upsun.com/oauth2/device"
+		CertifierURL        string `validate:"required_without=AuthURL,omitempty,url" yaml:"certifier_url,omitempty"`     // No longer used
 
 		AIServiceURL        string `validate:"omitempty,url" yaml:"ai_url,omitempty"`    // The AI service URL, e.g. "https://ai.upsun.com".
 		EnableOrganizations bool   `validate:"omitempty" yaml:"organizations,omitempty"` // Whether the "organizations" feature is enabled.
@@ -105,6 +119,24 @@ func (c *Config) applyDynamicDefaults() {
 	if c.Application.WritableUserDir == "" {
 		c.Application.WritableUserDir = c.Application.UserConfigDir
 	}
+	if c.API.SessionID == "" {
+		c.API.SessionID = "default"
+	}
+	if c.API.AuthURL != "" {
+		authURL := strings.TrimRight(c.API.AuthURL, "/")
+		if c.API.OAuth2AuthorizeURL == "" {
+			c.API.OAuth2AuthorizeURL = authURL + "/oauth2/authorize"
+		}
+		if c.API.OAuth2RevokeURL == "" {
+			c.API.OAuth2RevokeURL = authURL + "/oauth2/revoke"
+		}
+		if c.API.OAuth2TokenURL == "" {
+			c.API.OAuth2TokenURL = authURL + "/oauth2/token"
+		}
+		if c.API.OAuth2DeviceAuthURL == "" {
+			c.API.OAuth2DeviceAuthURL = authURL + "/oauth2/device"
+		}
+	}
 	if c.SourceFile == "" {
 		if path := os.Getenv("CLI_CONFIG_FILE"); path != "" {
 			c.SourceFile = path
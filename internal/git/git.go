@@ -0,0 +1,57 @@
+// Package git provides helpers for reading information from a local Git repository.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// run runs a Git command in a directory, returning its trimmed output.
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// RemoteURL returns the URL of a named remote, or an empty string if it does not exist
+// (or if the directory is not in a Git repository).
+func RemoteURL(ctx context.Context, dir, name string) string {
+	u, _ := run(ctx, dir, "config", "--get", "remote."+name+".url")
+	return u
+}
+
+// CurrentBranch returns the currently checked out branch, or an empty string
+// if there is none (e.g. in a detached HEAD state).
+func CurrentBranch(ctx context.Context, dir string) string {
+	b, _ := run(ctx, dir, "symbolic-ref", "--short", "-q", "HEAD")
+	return b
+}
+
+// SetRemote adds a remote, or changes its URL if it already exists.
+func SetRemote(ctx context.Context, dir, name, url string) error {
+	if RemoteURL(ctx, dir, name) != "" {
+		_, err := run(ctx, dir, "remote", "set-url", name, url)
+		return err
+	}
+	_, err := run(ctx, dir, "remote", "add", name, url)
+	return err
+}
+
+// Root returns the top-level directory of the repository containing dir, or
+// an empty string if it is not in a Git repository.
+func Root(ctx context.Context, dir string) string {
+	r, _ := run(ctx, dir, "rev-parse", "--show-toplevel")
+	return r
+}
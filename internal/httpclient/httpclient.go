@@ -0,0 +1,148 @@
+// Package httpclient creates HTTP transports which use the configured proxy,
+// custom CA bundle and client certificate.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/platformsh/cli/internal/config"
+)
+
+// Settings are the HTTP settings, from the config and environment variables.
+type Settings struct {
+	// Proxy is the proxy URL, possibly including credentials. If it is nil,
+	// the standard environment variables (HTTPS_PROXY, etc.) are used.
+	Proxy *url.URL
+	// CABundle is the path to a PEM file of trusted CA certificates, which
+	// replaces the system's certificate pool.
+	CABundle string
+	// ClientCert and ClientKey are paths to a PEM client certificate and key,
+	// for mutual TLS. They are not applied to the legacy CLI, which has no
+	// client certificate setting.
+	ClientCert string
+	ClientKey  string
+}
+
+// LoadSettings reads the HTTP settings from the config, overridden by environment variables.
+func LoadSettings(cnf *config.Config) (*Settings, error) {
+	envPrefix := cnf.Application.EnvPrefix
+	envOr := func(name, fallback string) string {
+		if v := os.Getenv(envPrefix + name); v != "" {
+			return v
+		}
+		return fallback
+	}
+
+	s := &Settings{
+		CABundle:   envOr("CA_BUNDLE", cnf.HTTP.CABundle),
+		ClientCert: envOr("CLIENT_CERT", cnf.HTTP.ClientCert),
+		ClientKey:  envOr("CLIENT_KEY", cnf.HTTP.ClientKey),
+	}
+	if s.ClientKey == "" {
+		// The key may be in the same file as the certificate.
+		s.ClientKey = s.ClientCert
+	}
+	if proxy := envOr("PROXY", cnf.HTTP.Proxy); proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL: %s", proxy)
+		}
+		if username := os.Getenv(envPrefix + "PROXY_USERNAME"); username != "" {
+			u.User = url.UserPassword(username, os.Getenv(envPrefix+"PROXY_PASSWORD"))
+		}
+		s.Proxy = u
+	}
+	return s, nil
+}
+
+// TLSConfig returns the TLS configuration, or nil if the defaults should be used.
+func (s *Settings) TLSConfig() (*tls.Config, error) {
+	if s.CABundle == "" && s.ClientCert == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if s.CABundle != "" {
+		pem, err := os.ReadFile(s.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle: %s", s.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if s.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(s.ClientCert, s.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// NewTransport creates an HTTP transport using the HTTP settings.
+//
+// If the settings are invalid, the transport returns the error from every request.
+func NewTransport(cnf *config.Config) http.RoundTripper {
+	s, err := LoadSettings(cnf)
+	if err != nil {
+		return errorTransport{err}
+	}
+	tlsConfig, err := s.TLSConfig()
+	if err != nil {
+		return errorTransport{err}
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if s.Proxy != nil {
+		t.Proxy = http.ProxyURL(s.Proxy)
+	}
+	if tlsConfig != nil {
+		t.TLSClientConfig = tlsConfig
+	}
+	return t
+}
+
+// NewClient creates an HTTP client using the HTTP settings.
+func NewClient(cnf *config.Config, timeout time.Duration) *http.Client {
+	return &http.Client{Transport: NewTransport(cnf), Timeout: timeout}
+}
+
+// LegacyEnv returns environment variables that apply the HTTP settings to the
+// legacy CLI. The client certificate is not included, as the legacy CLI cannot
+// use one.
+func (s *Settings) LegacyEnv(envPrefix string) []string {
+	var env []string
+	if s.Proxy != nil {
+		p := s.Proxy.String()
+		env = append(env, "HTTPS_PROXY="+p, "https_proxy="+p, "HTTP_PROXY="+p, "http_proxy="+p)
+	}
+	if s.CABundle != "" {
+		env = append(env, envPrefix+"CA_BUNDLE="+s.CABundle, "SSL_CERT_FILE="+s.CABundle)
+	}
+	return env
+}
+
+// LegacyPHPSettings returns PHP ini settings that apply the HTTP settings to the legacy CLI.
+func (s *Settings) LegacyPHPSettings() []string {
+	if s.CABundle == "" {
+		return nil
+	}
+	return []string{"openssl.cafile=" + s.CABundle, "curl.cainfo=" + s.CABundle}
+}
+
+type errorTransport struct{ err error }
+
+func (t errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	return nil, t.err
+}
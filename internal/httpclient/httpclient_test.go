@@ -0,0 +1,131 @@
+package httpclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/internal/config"
+)
+
+func testConfig() *config.Config {
+	cnf := &config.Config{}
+	cnf.Application.EnvPrefix = "TEST_CLI_"
+	return cnf
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+}
+
+func TestCABundleAndClientCert(t *testing.T) {
+	dir := t.TempDir()
+
+	// Create a client certificate, which the server requires.
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	clientDER, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &clientKey.PublicKey, clientKey)
+	require.NoError(t, err)
+	clientCert, err := x509.ParseCertificate(clientDER)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(clientKey)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "client.crt"), "CERTIFICATE", clientDER)
+	writePEM(t, filepath.Join(dir, "client.key"), "PRIVATE KEY", keyDER)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(req.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+	writePEM(t, filepath.Join(dir, "ca.pem"), "CERTIFICATE", server.Certificate().Raw)
+
+	cnf := testConfig()
+
+	// The server's certificate is not trusted by default.
+	_, err = NewClient(cnf, 0).Get(server.URL)
+	assert.Error(t, err)
+
+	// The client certificate is required.
+	t.Setenv("TEST_CLI_CA_BUNDLE", filepath.Join(dir, "ca.pem"))
+	_, err = NewClient(cnf, 0).Get(server.URL)
+	assert.Error(t, err)
+
+	cnf.HTTP.ClientCert = filepath.Join(dir, "client.crt")
+	cnf.HTTP.ClientKey = filepath.Join(dir, "client.key")
+	resp, err := NewClient(cnf, 0).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The legacy CLI only receives the CA bundle.
+	s, err := LoadSettings(cnf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TEST_CLI_CA_BUNDLE=" + filepath.Join(dir, "ca.pem"),
+		"SSL_CERT_FILE=" + filepath.Join(dir, "ca.pem")}, s.LegacyEnv("TEST_CLI_"))
+
+	// Invalid settings are returned as errors from requests.
+	t.Setenv("TEST_CLI_CA_BUNDLE", filepath.Join(dir, "missing.pem"))
+	_, err = NewClient(cnf, 0).Get(server.URL)
+	assert.ErrorContains(t, err, "could not read CA bundle")
+}
+
+func TestProxy(t *testing.T) {
+	var proxyAuth string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		proxyAuth = req.Header.Get("Proxy-Authorization")
+		_, _ = w.Write([]byte("proxied " + req.URL.String()))
+	}))
+	defer proxy.Close()
+
+	cnf := testConfig()
+	cnf.HTTP.Proxy = proxy.URL
+	t.Setenv("TEST_CLI_PROXY_USERNAME", "user")
+	t.Setenv("TEST_CLI_PROXY_PASSWORD", "pass")
+
+	resp, err := NewClient(cnf, 0).Get("http://example.com/path")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", proxyAuth)
+
+	s, err := LoadSettings(cnf)
+	require.NoError(t, err)
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+	proxyURL.User = url.UserPassword("user", "pass")
+	assert.Contains(t, s.LegacyEnv("TEST_CLI_"), "HTTPS_PROXY="+proxyURL.String())
+
+	t.Setenv("TEST_CLI_PROXY", "://invalid")
+	_, err = LoadSettings(cnf)
+	assert.Error(t, err)
+}
@@ -60,7 +60,7 @@ func RunAIConfig(
 		opts.HTTPClient = http.DefaultClient
 	}
 
-	spinr := defaultSpinner(stderr)
+	spinr := NewSpinner(stderr)
 	defer spinr.Stop()
 
 	if !files.IsLocal(path) {
@@ -46,7 +46,8 @@ func colorFunc(name string) func(string, ...any) string {
 	return defaultColorFunc
 }
 
-func defaultSpinner(w io.Writer) *spinner.Spinner {
+// NewSpinner creates a spinner with the default style, which only runs in a terminal.
+func NewSpinner(w io.Writer) *spinner.Spinner {
 	return spinner.New(spinner.CharSets[23], 80*time.Millisecond, spinner.WithWriter(w))
 }
 
@@ -18,6 +18,7 @@ import (
 
 	"github.com/platformsh/cli/internal/config"
 	"github.com/platformsh/cli/internal/file"
+	"github.com/platformsh/cli/internal/httpclient"
 )
 
 //go:embed archives/platform.phar
@@ -30,6 +31,11 @@ var (
 
 const configBasename = "config.yaml"
 
+// Available reports whether the legacy CLI and PHP are embedded in this build.
+func Available() bool {
+	return len(phar) > 0 && len(phpCLI) > 0
+}
+
 // CLIWrapper wraps the legacy CLI
 type CLIWrapper struct {
 	Stdout             io.Writer
@@ -130,7 +136,11 @@ func (c *CLIWrapper) Exec(ctx context.Context, args ...string) error {
 	if err != nil {
 		return err
 	}
-	cmd := c.makeCmd(ctx, args, cacheDir)
+	httpSettings, err := httpclient.LoadSettings(c.Config)
+	if err != nil {
+		return err
+	}
+	cmd := c.makeCmd(ctx, args, cacheDir, httpSettings.LegacyPHPSettings()...)
 	cmd.Stdin = c.Stdin
 	cmd.Stdout = c.Stdout
 	cmd.Stderr = c.Stderr
@@ -145,6 +155,13 @@ func (c *CLIWrapper) Exec(ctx context.Context, args ...string) error {
 		envPrefix+"WRAPPED=1",
 		envPrefix+"APPLICATION_VERSION="+c.Version,
 	)
+	cmd.Env = append(cmd.Env, httpSettings.LegacyEnv(envPrefix)...)
+	if httpSettings.ClientCert != "" {
+		c.debug("The client certificate is not used by the legacy CLI")
+	}
+	if c.Config.API.SessionID != "" {
+		cmd.Env = append(cmd.Env, envPrefix+"SESSION_ID="+c.Config.API.SessionID)
+	}
 	if c.DisableInteraction {
 		cmd.Env = append(cmd.Env, envPrefix+"NO_INTERACTION=1")
 	}
@@ -166,9 +183,10 @@ func (c *CLIWrapper) Exec(ctx context.Context, args ...string) error {
 }
 
 // makeCmd makes a legacy CLI command with the given context and arguments.
-func (c *CLIWrapper) makeCmd(ctx context.Context, args []string, cacheDir string) *exec.Cmd {
+// Extra PHP settings override the defaults.
+func (c *CLIWrapper) makeCmd(ctx context.Context, args []string, cacheDir string, extraSettings ...string) *exec.Cmd {
 	phpMgr := newPHPManager(cacheDir)
-	settings := phpMgr.settings()
+	settings := append(phpMgr.settings(), extraSettings...)
 	var cmdArgs = make([]string, 0, len(args)+2+len(settings)*2)
 	for _, s := range settings {
 		cmdArgs = append(cmdArgs, "-d", s)
@@ -0,0 +1,250 @@
+// Package selection resolves the project and environment that a command
+// applies to, from flags, environment variables, the project's local
+// configuration and its Git repository.
+package selection
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/git"
+)
+
+// gitRemotePattern matches a project's Git remote URL, in either SCP-like
+// form ("abc@git.region.example.com:abc.git") or ssh:// form.
+var gitRemotePattern = regexp.MustCompile(`^(?:ssh://)?[a-z0-9]+@git\.[^:/]+[:/]([a-z0-9]+)\.git$`)
+
+// projectIDPattern matches a valid project ID.
+var projectIDPattern = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// Input is the explicit input for a selection, usually from command flags.
+type Input struct {
+	Project     string // A project ID or URL, e.g. from the --project flag.
+	Environment string // An environment ID, e.g. from the --environment flag.
+	Dir         string // The working directory.
+}
+
+// Result is a resolved project and environment, with the reasons they were
+// chosen. The IDs are empty if they could not be determined.
+type Result struct {
+	ProjectID         string
+	ProjectReason     string
+	EnvironmentID     string
+	EnvironmentReason string
+}
+
+// Resolver resolves the project and environment, in order of precedence from:
+//
+//  1. The input (flags), where the project may also be a URL.
+//  2. Environment variables: the CLI's own PROJECT and ENVIRONMENT, then the
+//     service's PROJECT and BRANCH (which are set inside an app container).
+//  3. The project ID in the local project file, e.g. .platform/local/project.yaml.
+//  4. The project's Git remote (named in Config.Detection.GitRemoteName).
+//  5. The current Git branch, for the environment.
+//
+// The environment is only taken from the service's BRANCH variable or the
+// current Git branch if they belong to the selected project.
+type Resolver struct {
+	Config *config.Config
+
+	// Getenv reads environment variables. It defaults to os.Getenv.
+	Getenv func(string) string
+
+	// Debug, if set, is called to explain why a project or environment was chosen.
+	Debug func(format string, args ...any)
+}
+
+// Resolve resolves both the project and environment.
+func (r *Resolver) Resolve(ctx context.Context, in Input) *Result {
+	res := &Result{}
+	res.ProjectID, res.ProjectReason = r.Project(ctx, in)
+	res.EnvironmentID, res.EnvironmentReason = r.Environment(ctx, in)
+	return res
+}
+
+// Project returns the project ID, and the reason it was chosen.
+func (r *Resolver) Project(ctx context.Context, in Input) (id, reason string) {
+	id, reason = r.project(ctx, in)
+	if id != "" {
+		r.debug("Selected project %s from %s", id, reason)
+	}
+	return id, reason
+}
+
+func (r *Resolver) project(ctx context.Context, in Input) (id, reason string) {
+	if in.Project != "" {
+		if projectID, _, ok := ParseURL(r.Config, in.Project); ok {
+			return projectID, "the URL " + in.Project
+		}
+		return in.Project, "the --project flag"
+	}
+	for _, name := range r.envVarNames("PROJECT", "PROJECT") {
+		if v := r.getenv(name); v != "" {
+			return v, "the environment variable " + name
+		}
+	}
+	return r.repositoryProject(ctx, in.Dir)
+}
+
+// repositoryProject returns the project of the Git repository containing
+// dir, from the local project file or the Git remote.
+func (r *Resolver) repositoryProject(ctx context.Context, dir string) (id, reason string) {
+	root := git.Root(ctx, dir)
+	if root == "" {
+		return "", ""
+	}
+	if projectID, file := r.projectFromLocalConfig(root); projectID != "" {
+		return projectID, "the file " + file
+	}
+	remoteName := r.Config.Detection.GitRemoteName
+	if remoteURL := git.RemoteURL(ctx, root, remoteName); remoteURL != "" {
+		if projectID, _, ok := ParseURL(r.Config, remoteURL); ok {
+			return projectID, fmt.Sprintf("the Git remote %q (%s)", remoteName, remoteURL)
+		}
+		r.debug("The Git remote %q does not match a project: %s", remoteName, remoteURL)
+	}
+	return "", ""
+}
+
+// Environment returns the environment ID, and the reason it was chosen. The
+// service's BRANCH variable and the current Git branch are ignored if another
+// project was selected, e.g. with --project.
+func (r *Resolver) Environment(ctx context.Context, in Input) (id, reason string) {
+	defer func() {
+		if id != "" {
+			r.debug("Selected environment %s from %s", id, reason)
+		}
+	}()
+	if in.Environment != "" {
+		return in.Environment, "the --environment flag"
+	}
+	if in.Project != "" {
+		if _, envID, ok := ParseURL(r.Config, in.Project); ok && envID != "" {
+			return envID, "the URL " + in.Project
+		}
+	}
+	projectID, _ := r.project(ctx, in)
+	if p := r.Config.Application.EnvPrefix; p != "" {
+		if v := r.getenv(p + "ENVIRONMENT"); v != "" {
+			return v, "the environment variable " + p + "ENVIRONMENT"
+		}
+	}
+	if p := r.Config.Service.EnvPrefix; p != "" {
+		if v := r.getenv(p + "BRANCH"); v != "" {
+			if serviceProjectID := r.getenv(p + "PROJECT"); projectID == "" || projectID == serviceProjectID {
+				return v, "the environment variable " + p + "BRANCH"
+			}
+			r.debug("Ignoring the environment variable %sBRANCH, as it does not belong to the project %s",
+				p, projectID)
+		}
+	}
+	if branch := git.CurrentBranch(ctx, in.Dir); branch != "" {
+		if repoProjectID, _ := r.repositoryProject(ctx, in.Dir); projectID == "" || projectID == repoProjectID {
+			return branch, "the current Git branch"
+		}
+		r.debug("Ignoring the current Git branch, as the repository does not belong to the project %s", projectID)
+	}
+	return "", ""
+}
+
+// envVarNames returns the environment variables to check, in order: the
+// CLI's variable (with cliSuffix), then the service's (with serviceSuffix).
+func (r *Resolver) envVarNames(cliSuffix, serviceSuffix string) []string {
+	var names []string
+	if p := r.Config.Application.EnvPrefix; p != "" {
+		names = append(names, p+cliSuffix)
+	}
+	if p := r.Config.Service.EnvPrefix; p != "" {
+		names = append(names, p+serviceSuffix)
+	}
+	return names
+}
+
+// projectFromLocalConfig reads the project ID from the local project file in
+// a repository, returning the ID and the file's path relative to the root.
+func (r *Resolver) projectFromLocalConfig(root string) (projectID, file string) {
+	if r.Config.Service.ProjectConfigDir == "" {
+		return "", ""
+	}
+	file = filepath.Join(r.Config.Service.ProjectConfigDir, "local", "project.yaml")
+	b, err := os.ReadFile(filepath.Join(root, file))
+	if err != nil {
+		return "", ""
+	}
+	var local struct {
+		ID string `yaml:"id"`
+	}
+	if err := yaml.Unmarshal(b, &local); err != nil {
+		r.debug("Could not parse %s: %s", file, err)
+		return "", ""
+	}
+	return local.ID, file
+}
+
+func (r *Resolver) getenv(name string) string {
+	if r.Getenv != nil {
+		return r.Getenv(name)
+	}
+	return os.Getenv(name)
+}
+
+func (r *Resolver) debug(format string, args ...any) {
+	if r.Debug != nil {
+		r.Debug(format, args...)
+	}
+}
+
+// ParseURL finds a project ID, and possibly an environment ID, in a URL. It
+// recognizes project Git remote URLs, the URLs of environments on the
+// service's site domains (Config.Detection.SiteDomains), and console URLs.
+func ParseURL(cnf *config.Config, s string) (projectID, environmentID string, ok bool) {
+	if m := gitRemotePattern.FindStringSubmatch(s); m != nil {
+		return m[1], "", true
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", "", false
+	}
+	host := strings.ToLower(u.Hostname())
+
+	// Site URLs are in the form [subdomain.]{environment}-{project}.{region}.{domain}.
+	for _, domain := range cnf.Detection.SiteDomains {
+		prefix, found := strings.CutSuffix(host, "."+domain)
+		if !found {
+			continue
+		}
+		labels := strings.Split(prefix, ".")
+		if len(labels) < 2 {
+			continue
+		}
+		envAndProject := labels[len(labels)-2]
+		i := strings.LastIndex(envAndProject, "-")
+		if i <= 0 || !projectIDPattern.MatchString(envAndProject[i+1:]) {
+			continue
+		}
+		return envAndProject[i+1:], envAndProject[:i], true
+	}
+
+	// Console URLs are in the form /{organization}/{project}[/{environment}].
+	if cnf.Service.ConsoleURL != "" {
+		if c, err := url.Parse(cnf.Service.ConsoleURL); err == nil && strings.EqualFold(c.Hostname(), host) {
+			segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+			if len(segments) < 2 || !projectIDPattern.MatchString(segments[1]) {
+				return "", "", false
+			}
+			if len(segments) > 2 && segments[2] != "-" {
+				environmentID = segments[2]
+			}
+			return segments[1], environmentID, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,163 @@
+package selection
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platformsh/cli/internal/config"
+)
+
+func testConfig() *config.Config {
+	cnf := &config.Config{}
+	cnf.Application.EnvPrefix = "TEST_CLI_"
+	cnf.Service.EnvPrefix = "TEST_"
+	cnf.Service.ProjectConfigDir = ".test"
+	cnf.Service.ConsoleURL = "https://console.example.com"
+	cnf.Detection.GitRemoteName = "test"
+	cnf.Detection.SiteDomains = []string{"example.site"}
+	return cnf
+}
+
+func TestParseURL(t *testing.T) {
+	cnf := testConfig()
+	cases := []struct {
+		url         string
+		projectID   string
+		environment string
+		ok          bool
+	}{
+		{"abc123@git.region.example.com:abc123.git", "abc123", "", true},
+		{"ssh://abc123@git.region.example.com/abc123.git", "abc123", "", true},
+		{"https://main-bvxea6i-abc123.region.example.site/", "abc123", "main-bvxea6i", true},
+		{"https://www.feature-x-yz12ab-abc123.region.example.site/path", "abc123", "feature-x-yz12ab", true},
+		{"https://console.example.com/my-org/abc123/staging", "abc123", "staging", true},
+		{"https://console.example.com/my-org/abc123/-/settings", "abc123", "", true},
+		{"https://console.example.com/my-org", "", "", false},
+		{"https://region.example.site/", "", "", false},
+		{"https://example.com/abc123", "", "", false},
+		{"git@github.com:example/repo.git", "", "", false},
+		{"abc123", "", "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.url, func(t *testing.T) {
+			projectID, environmentID, ok := ParseURL(cnf, c.url)
+			assert.Equal(t, c.ok, ok)
+			assert.Equal(t, c.projectID, projectID)
+			assert.Equal(t, c.environment, environmentID)
+		})
+	}
+}
+
+func TestResolverPrecedence(t *testing.T) {
+	ctx := context.Background()
+	cnf := testConfig()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--initial-branch", "feature"},
+		{"remote", "add", "test", "remote1@git.region.example.com:remote1.git"},
+	} {
+		require.NoError(t, exec.Command("git", append([]string{"-C", dir}, args...)...).Run())
+	}
+
+	env := map[string]string{}
+	var debugMessages []string
+	r := &Resolver{
+		Config: cnf,
+		Getenv: func(name string) string { return env[name] },
+		Debug: func(format string, args ...any) {
+			debugMessages = append(debugMessages, fmt.Sprintf(format, args...))
+		},
+	}
+	resolve := func(in Input) *Result {
+		in.Dir = dir
+		return r.Resolve(ctx, in)
+	}
+
+	res := resolve(Input{})
+	assert.Equal(t, &Result{
+		ProjectID:         "remote1",
+		ProjectReason:     `the Git remote "test" (remote1@git.region.example.com:remote1.git)`,
+		EnvironmentID:     "feature",
+		EnvironmentReason: "the current Git branch",
+	}, res)
+	assert.Equal(t, []string{
+		`Selected project remote1 from the Git remote "test" (remote1@git.region.example.com:remote1.git)`,
+		"Selected environment feature from the current Git branch",
+	}, debugMessages)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".test", "local"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".test", "local", "project.yaml"), []byte("id: local1\n"), 0o600))
+	res = resolve(Input{})
+	assert.Equal(t, "local1", res.ProjectID)
+	assert.Equal(t, "the file "+filepath.Join(".test", "local", "project.yaml"), res.ProjectReason)
+
+	env["TEST_PROJECT"] = "service1"
+	env["TEST_BRANCH"] = "main"
+	res = resolve(Input{})
+	assert.Equal(t, "service1", res.ProjectID)
+	assert.Equal(t, "main", res.EnvironmentID)
+	assert.Equal(t, "the environment variable TEST_BRANCH", res.EnvironmentReason)
+
+	env["TEST_CLI_PROJECT"] = "cli1"
+	env["TEST_CLI_ENVIRONMENT"] = "staging"
+	res = resolve(Input{})
+	assert.Equal(t, "cli1", res.ProjectID)
+	assert.Equal(t, "staging", res.EnvironmentID)
+
+	res = resolve(Input{Project: "https://dev-ab12cd-url1.region.example.site"})
+	assert.Equal(t, "url1", res.ProjectID)
+	assert.Equal(t, "dev-ab12cd", res.EnvironmentID)
+
+	res = resolve(Input{Project: "https://dev-ab12cd-url1.region.example.site", Environment: "flag-env"})
+	assert.Equal(t, "url1", res.ProjectID)
+	assert.Equal(t, "flag-env", res.EnvironmentID)
+	assert.Equal(t, "the --environment flag", res.EnvironmentReason)
+
+	res = resolve(Input{Project: "flag1"})
+	assert.Equal(t, "flag1", res.ProjectID)
+	assert.Equal(t, "the --project flag", res.ProjectReason)
+}
+
+func TestResolverEnvironmentOfOtherProject(t *testing.T) {
+	ctx := context.Background()
+	cnf := testConfig()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--initial-branch", "feature"},
+		{"remote", "add", "test", "remote1@git.region.example.com:remote1.git"},
+	} {
+		require.NoError(t, exec.Command("git", append([]string{"-C", dir}, args...)...).Run())
+	}
+	env := map[string]string{}
+	r := &Resolver{Config: cnf, Getenv: func(name string) string { return env[name] }}
+
+	// The current branch belongs to the repository's project.
+	res := r.Resolve(ctx, Input{Project: "remote1", Dir: dir})
+	assert.Equal(t, "feature", res.EnvironmentID)
+	res = r.Resolve(ctx, Input{Project: "other", Dir: dir})
+	assert.Equal(t, "other", res.ProjectID)
+	assert.Empty(t, res.EnvironmentID)
+	res = r.Resolve(ctx, Input{Project: "https://console.example.com/my-org/other", Dir: dir})
+	assert.Empty(t, res.EnvironmentID)
+
+	// The service's branch belongs to the service's project.
+	env["TEST_PROJECT"] = "service1"
+	env["TEST_BRANCH"] = "main"
+	res = r.Resolve(ctx, Input{Project: "other", Dir: dir})
+	assert.Empty(t, res.EnvironmentID)
+	res = r.Resolve(ctx, Input{Dir: dir})
+	assert.Equal(t, "service1", res.ProjectID)
+	assert.Equal(t, "main", res.EnvironmentID)
+
+	// The CLI's own variables are explicit choices.
+	env["TEST_CLI_ENVIRONMENT"] = "staging"
+	res = r.Resolve(ctx, Input{Project: "other", Dir: dir})
+	assert.Equal(t, "staging", res.EnvironmentID)
+}
@@ -0,0 +1,46 @@
+package sshcert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/platformsh/cli/internal/file"
+)
+
+// ConfigPath returns the path to the OpenSSH configuration fragment, which
+// users can reference from their own configuration with an "Include" directive.
+func (cf *Certifier) ConfigPath() (string, error) {
+	writableDir, err := cf.cnf.WritableUserDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(writableDir, "ssh", cf.cnf.Application.Slug+".config"), nil
+}
+
+// WriteConfig writes the OpenSSH configuration fragment, so that connections to
+// hosts matching the configured domain wildcards use the certificate.
+func (cf *Certifier) WriteConfig(cert *Certificate) (string, error) {
+	path, err := cf.ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	if err := file.WriteIfNeeded(path, []byte(cf.configContent(cert)), 0o600); err != nil {
+		return "", fmt.Errorf("could not write SSH configuration: %w", err)
+	}
+	return path, nil
+}
+
+func (cf *Certifier) configContent(cert *Certificate) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# This file is generated by the %s. Changes will be overwritten.\n",
+		cf.cnf.Application.Name)
+	fmt.Fprintf(&b, "Host %s\n", strings.Join(cf.cnf.SSH.DomainWildcards, " "))
+	fmt.Fprintf(&b, "  IdentityFile \"%s\"\n", cert.KeyPath)
+	fmt.Fprintf(&b, "  CertificateFile \"%s\"\n", cert.CertPath)
+	return b.String()
+}
@@ -0,0 +1,241 @@
+// Package sshcert generates SSH keys and obtains certificates for them from the
+// certificate authority, so that users can connect to environments via SSH.
+//
+// SSH connections are still made by the legacy CLI, which manages its own
+// certificates: no command uses this package yet.
+package sshcert
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/platformsh/cli/internal/auth"
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/file"
+)
+
+const (
+	keyFilename  = "id_ed25519"
+	certFilename = keyFilename + "-cert.pub"
+
+	// expiryMargin is how long before the end of its validity a certificate is
+	// considered expired, to avoid it expiring during a connection attempt.
+	expiryMargin = 30 * time.Second
+)
+
+// Certifier manages the SSH key and certificate of the current authentication session.
+type Certifier struct {
+	cnf        *config.Config
+	httpClient *http.Client
+
+	// Dir is the directory containing the key and certificate files.
+	Dir string
+}
+
+// Certificate is a loaded SSH certificate along with the paths to its files.
+type Certificate struct {
+	Cert     *ssh.Certificate
+	KeyPath  string
+	CertPath string
+}
+
+// New creates a Certifier. The HTTP client must be authenticated, e.g. using
+// auth.NewLegacyCLIClient.
+//
+// Keys and certificates are stored per session and per user (see
+// auth.IdentityKey), so that a certificate issued for one user is not used for
+// another, e.g. when an API token is set in the environment.
+func New(cnf *config.Config, httpClient *http.Client) (*Certifier, error) {
+	if cnf.API.AuthURL == "" {
+		return nil, errors.New("no auth URL configured")
+	}
+	store, err := auth.NewSessionStore(cnf)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := auth.IdentityKey(cnf)
+	if err != nil {
+		return nil, err
+	}
+	if identity == "" {
+		return nil, auth.ErrNoSession
+	}
+	return &Certifier{cnf: cnf, httpClient: httpClient, Dir: filepath.Join(store.Dir, "ssh", identity)}, nil
+}
+
+// Valid returns whether the certificate is within its validity window.
+func (c *Certificate) Valid() bool {
+	now := time.Now()
+	validAfter := time.Unix(int64(c.Cert.ValidAfter), 0)   //nolint:gosec // G115
+	validBefore := time.Unix(int64(c.Cert.ValidBefore), 0) //nolint:gosec // G115
+	return !now.Before(validAfter) && now.Add(expiryMargin).Before(validBefore)
+}
+
+// Signer returns an SSH signer using the certificate and its private key.
+func (c *Certificate) Signer() (ssh.Signer, error) {
+	keyData, err := os.ReadFile(c.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ssh.ParsePrivateKey(keyData)
+	if err != nil {
+		return nil, err
+	}
+	return ssh.NewCertSigner(c.Cert, key)
+}
+
+// Certificate returns a valid certificate, loading it from disk if one is
+// cached, or otherwise generating a key and requesting a new certificate.
+func (cf *Certifier) Certificate(ctx context.Context) (*Certificate, error) {
+	if cert, err := cf.load(); err == nil && cert.Valid() {
+		return cert, nil
+	}
+	return cf.Refresh(ctx)
+}
+
+// Refresh requests a new certificate, generating a new key if needed.
+func (cf *Certifier) Refresh(ctx context.Context) (*Certificate, error) {
+	if err := os.MkdirAll(cf.Dir, 0o700); err != nil {
+		return nil, err
+	}
+	pub, err := cf.publicKey()
+	if err != nil {
+		return nil, err
+	}
+	certData, err := cf.requestCertificate(ctx, pub)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Write(cf.certPath(), certData, 0o600); err != nil {
+		return nil, fmt.Errorf("could not save SSH certificate: %w", err)
+	}
+	cert, err := cf.load()
+	if err != nil {
+		return nil, err
+	}
+	if !cert.Valid() {
+		return nil, errors.New("the new SSH certificate is not valid")
+	}
+	return cert, nil
+}
+
+// Delete removes the key and certificate.
+func (cf *Certifier) Delete() error {
+	if err := os.RemoveAll(cf.Dir); err != nil {
+		return fmt.Errorf("could not delete SSH certificate: %w", err)
+	}
+	return nil
+}
+
+func (cf *Certifier) keyPath() string  { return filepath.Join(cf.Dir, keyFilename) }
+func (cf *Certifier) certPath() string { return filepath.Join(cf.Dir, certFilename) }
+
+// load loads the certificate from disk, checking that it belongs to the stored key.
+func (cf *Certifier) load() (*Certificate, error) {
+	certData, err := os.ReadFile(cf.certPath())
+	if err != nil {
+		return nil, err
+	}
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey(certData) //nolint:dogsled
+	if err != nil {
+		return nil, fmt.Errorf("could not parse SSH certificate: %w", err)
+	}
+	cert, ok := parsed.(*ssh.Certificate)
+	if !ok {
+		return nil, errors.New("the SSH certificate file does not contain a certificate")
+	}
+	pubData, err := os.ReadFile(cf.keyPath() + ".pub")
+	if err != nil {
+		return nil, err
+	}
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(pubData) //nolint:dogsled
+	if err != nil {
+		return nil, fmt.Errorf("could not parse SSH public key: %w", err)
+	}
+	if !bytes.Equal(cert.Key.Marshal(), pub.Marshal()) {
+		return nil, errors.New("the SSH certificate does not match the key")
+	}
+	return &Certificate{Cert: cert, KeyPath: cf.keyPath(), CertPath: cf.certPath()}, nil
+}
+
+// publicKey returns the stored public key, generating a new key pair if none exists.
+func (cf *Certifier) publicKey() (ssh.PublicKey, error) {
+	if pubData, err := os.ReadFile(cf.keyPath() + ".pub"); err == nil {
+		if _, err := os.Stat(cf.keyPath()); err == nil {
+			pub, _, _, _, err := ssh.ParseAuthorizedKey(pubData) //nolint:dogsled
+			if err == nil {
+				return pub, nil
+			}
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	block, err := ssh.MarshalPrivateKey(privKey, "")
+	if err != nil {
+		return nil, err
+	}
+	pub, err := ssh.NewPublicKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Write(cf.keyPath(), pem.EncodeToMemory(block), 0o600); err != nil {
+		return nil, fmt.Errorf("could not save SSH key: %w", err)
+	}
+	if err := file.Write(cf.keyPath()+".pub", ssh.MarshalAuthorizedKey(pub), 0o644); err != nil {
+		return nil, fmt.Errorf("could not save SSH public key: %w", err)
+	}
+	return pub, nil
+}
+
+// requestCertificate asks the certificate authority to sign the public key.
+func (cf *Certifier) requestCertificate(ctx context.Context, pub ssh.PublicKey) ([]byte, error) {
+	body, err := json.Marshal(struct {
+		Key string `json:"key"`
+	}{string(ssh.MarshalAuthorizedKey(pub))})
+	if err != nil {
+		return nil, err
+	}
+	certURL := strings.TrimRight(cf.cnf.API.AuthURL, "/") + "/ssh"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, certURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := cf.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not request SSH certificate: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not request SSH certificate: unexpected status %s", resp.Status)
+	}
+	var data struct {
+		Certificate string `json:"certificate"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("could not decode SSH certificate response: %w", err)
+	}
+	if data.Certificate == "" {
+		return nil, errors.New("no SSH certificate in response")
+	}
+	return []byte(data.Certificate), nil
+}
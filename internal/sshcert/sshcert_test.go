@@ -0,0 +1,132 @@
+package sshcert_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/platformsh/cli/internal/auth"
+	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/legacy"
+	"github.com/platformsh/cli/internal/sshcert"
+	"github.com/platformsh/cli/pkg/mockapi"
+	"github.com/platformsh/cli/pkg/mockssh"
+)
+
+// recordingTransport records the Authorization header of certificate requests.
+type recordingTransport struct {
+	mu          sync.Mutex
+	authHeaders []string
+}
+
+func (r *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, "/ssh") {
+		r.mu.Lock()
+		r.authHeaders = append(r.authHeaders, req.Header.Get("Authorization"))
+		r.mu.Unlock()
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestCertifier(t *testing.T) {
+	authServer := mockapi.NewAuthServer(t)
+	defer authServer.Close()
+
+	sshServer, err := mockssh.NewServer(t, authServer.URL+"/ssh/authority")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = sshServer.Stop()
+	})
+
+	cnf := &config.Config{}
+	cnf.Application.Name = "Test CLI"
+	cnf.Application.EnvPrefix = "TEST_CLI_"
+	cnf.Application.Slug = "test-cli"
+	cnf.Application.WritableUserDir = ".test-cli"
+	cnf.API.AuthURL = authServer.URL
+	cnf.API.SessionID = "default"
+	cnf.API.OAuth2TokenURL = authServer.URL + "/oauth2/token"
+	cnf.SSH.DomainWildcards = []string{"*.ssh.example.com", "*.ssh.example.net"}
+	t.Setenv(cnf.Application.EnvPrefix+"HOME", t.TempDir())
+	t.Setenv(cnf.Application.EnvPrefix+"TOKEN", mockapi.ValidAPITokens[0])
+
+	rt := &recordingTransport{}
+	ctx := auth.WithTransport(context.Background(), rt)
+	client, err := auth.NewLegacyCLIClient(ctx, &legacy.CLIWrapper{Config: cnf})
+	require.NoError(t, err)
+
+	certifier, err := sshcert.New(cnf, client.HTTPClient)
+	require.NoError(t, err)
+
+	cert, err := certifier.Certificate(ctx)
+	require.NoError(t, err)
+	assert.True(t, cert.Valid())
+	assert.Equal(t, []string{"Bearer access-token-1"}, rt.authHeaders)
+
+	// The certificate is cached.
+	cert2, err := certifier.Certificate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, cert.Cert.Marshal(), cert2.Cert.Marshal())
+	assert.Len(t, rt.authHeaders, 1)
+
+	// Refreshing reuses the same key.
+	cert3, err := certifier.Refresh(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, cert.Cert.Key.Marshal(), cert3.Cert.Key.Marshal())
+	assert.Len(t, rt.authHeaders, 2)
+
+	configPath, err := certifier.WriteConfig(cert3)
+	require.NoError(t, err)
+	configContent, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(configContent), "Host *.ssh.example.com *.ssh.example.net\n")
+	assert.Contains(t, string(configContent), "  CertificateFile \""+cert3.CertPath+"\"\n")
+
+	// The certificate is accepted by the SSH server.
+	signer, err := cert3.Signer()
+	require.NoError(t, err)
+	address := fmt.Sprintf("127.0.0.1:%d", sshServer.Port())
+	sshClient, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User: "test",
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			if bytes.Equal(sshServer.HostKey().Marshal(), key.Marshal()) {
+				return nil
+			}
+			return fmt.Errorf("host key mismatch")
+		},
+	})
+	require.NoError(t, err)
+	defer sshClient.Close()
+
+	session, err := sshClient.NewSession()
+	require.NoError(t, err)
+	defer session.Close()
+	require.NoError(t, session.Run("true"))
+
+	// Deleting removes the certificate, so the next call requests a new one.
+	require.NoError(t, certifier.Delete())
+	cert4, err := certifier.Certificate(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, cert.Cert.Key.Marshal(), cert4.Cert.Key.Marshal())
+	assert.Len(t, rt.authHeaders, 3)
+
+	// Certificates are stored per user.
+	t.Setenv(cnf.Application.EnvPrefix+"TOKEN", "api-token-2")
+	other, err := sshcert.New(cnf, client.HTTPClient)
+	require.NoError(t, err)
+	assert.NotEqual(t, certifier.Dir, other.Dir)
+	t.Setenv(cnf.Application.EnvPrefix+"TOKEN", "")
+	_, err = sshcert.New(cnf, client.HTTPClient)
+	assert.ErrorIs(t, err, auth.ErrNoSession)
+}
@@ -16,6 +16,10 @@ type State struct {
 	ConfigUpdates struct {
 		LastChecked int64 `json:"last_checked"`
 	} `json:"config_updates,omitempty"`
+
+	Auth struct {
+		ActiveSessionID string `json:"active_session_id,omitempty"`
+	} `json:"auth,omitempty"`
 }
 
 // Load reads state from the filesystem.
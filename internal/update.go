@@ -11,6 +11,7 @@ import (
 	"github.com/symfony-cli/terminal"
 
 	"github.com/platformsh/cli/internal/config"
+	"github.com/platformsh/cli/internal/httpclient"
 	"github.com/platformsh/cli/internal/state"
 	"github.com/platformsh/cli/internal/version"
 )
@@ -41,7 +42,7 @@ func CheckForUpdate(cnf *config.Config, currentVersion string) (*ReleaseInfo, er
 		state.Save(s, cnf)
 	}()
 
-	releaseInfo, err := getLatestReleaseInfo(cnf.Wrapper.GitHubRepo)
+	releaseInfo, err := getLatestReleaseInfo(cnf)
 	if err != nil {
 		return nil, fmt.Errorf("could not determine latest release: %w", err)
 	}
@@ -73,13 +74,14 @@ func isCI() bool {
 }
 
 // getLatestReleaseInfo from GitHub
-func getLatestReleaseInfo(repo string) (*ReleaseInfo, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", repo), http.NoBody)
+func getLatestReleaseInfo(cnf *config.Config) (*ReleaseInfo, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", cnf.Wrapper.GitHubRepo), http.NoBody)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpclient.NewClient(cnf, 0).Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -22,12 +23,13 @@ func (h *Handler) handleListProjectActivities(w http.ResponseWriter, req *http.R
 }
 
 func (h *Handler) handleGetProjectActivity(w http.ResponseWriter, req *http.Request) {
-	h.RLock()
-	defer h.RUnlock()
+	h.Lock()
+	defer h.Unlock()
 	projectID := chi.URLParam(req, "project_id")
 	activityID := chi.URLParam(req, "id")
-	if projectActivities := h.activities[projectID]; projectActivities != nil {
-		_ = json.NewEncoder(w).Encode(projectActivities[activityID])
+	if a := h.activities[projectID][activityID]; a != nil {
+		a.nextTransition()
+		_ = json.NewEncoder(w).Encode(a)
 		return
 	}
 	w.WriteHeader(http.StatusNotFound)
@@ -50,8 +52,8 @@ func (h *Handler) handleListEnvironmentActivities(w http.ResponseWriter, req *ht
 }
 
 func (h *Handler) handleGetEnvironmentActivity(w http.ResponseWriter, req *http.Request) {
-	h.RLock()
-	defer h.RUnlock()
+	h.Lock()
+	defer h.Unlock()
 	projectID := chi.URLParam(req, "project_id")
 	activityID := chi.URLParam(req, "id")
 	if projectActivities := h.activities[projectID]; projectActivities != nil {
@@ -61,8 +63,40 @@ func (h *Handler) handleGetEnvironmentActivity(w http.ResponseWriter, req *http.
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
+		a.nextTransition()
 		_ = json.NewEncoder(w).Encode(a)
 		return
 	}
 	w.WriteHeader(http.StatusNotFound)
 }
+
+// handleActivityLog streams the activity's log as newline-delimited JSON,
+// optionally after a "start_at" time. The log is sealed if the activity is complete.
+func (h *Handler) handleActivityLog(w http.ResponseWriter, req *http.Request) {
+	h.RLock()
+	defer h.RUnlock()
+	a := h.activities[chi.URLParam(req, "project_id")][chi.URLParam(req, "id")]
+	if a == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var startAt time.Time
+	if s := req.URL.Query().Get("start_at"); s != "" {
+		var err error
+		if startAt, err = time.Parse(time.RFC3339, s); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	for _, entry := range a.log {
+		if entry.Timestamp.After(startAt) {
+			_ = enc.Encode(map[string]any{"data": entry, "seal": false})
+		}
+	}
+	if a.State == "complete" || a.State == "cancelled" {
+		_ = enc.Encode(map[string]any{"seal": true})
+	}
+}
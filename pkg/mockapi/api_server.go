@@ -50,7 +50,12 @@ func NewHandler(t *testing.T) *Handler {
 	h.Patch("/organizations/{organization_id}", h.handlePatchOrg)
 	h.Get("/users/{user_id}/organizations", h.handleListOrgs)
 	h.Get("/ref/organizations", h.handleOrgRefs)
+	h.Get("/organizations/{organization_id}/members", h.handleListOrgMembers)
+	h.Patch("/organizations/{organization_id}/members/{user_id}", h.handlePatchOrgMember)
+	h.Delete("/organizations/{organization_id}/members/{user_id}", h.handleDeleteOrgMember)
+	h.Post("/organizations/{organization_id}/invitations", h.handleCreateOrgInvitation)
 
+	h.Get("/organizations/{organization_id}/subscriptions", h.handleListOrgSubscriptions)
 	h.Post("/organizations/{organization_id}/subscriptions", h.handleCreateSubscription)
 	h.Get("/subscriptions/{subscription_id}", h.handleGetSubscription)
 	h.Get("/organizations/{organization_id}/subscriptions/{subscription_id}", h.handleGetSubscription)
@@ -74,16 +79,30 @@ func NewHandler(t *testing.T) *Handler {
 	h.Get("/projects/{project_id}/environments/{environment_id}/settings", h.handleGetEnvironmentSettings)
 	h.Patch("/projects/{project_id}/environments/{environment_id}/settings", h.handleSetEnvironmentSettings)
 	h.Post("/projects/{project_id}/environments/{environment_id}/deploy", h.handleDeployEnvironment)
+	h.Post("/projects/{project_id}/environments/{environment_id}/activate",
+		h.handleEnvironmentOperation("activate", "active"))
+	h.Post("/projects/{project_id}/environments/{environment_id}/pause",
+		h.handleEnvironmentOperation("pause", "paused"))
+	h.Post("/projects/{project_id}/environments/{environment_id}/resume",
+		h.handleEnvironmentOperation("resume", "active"))
+	h.Post("/projects/{project_id}/environments/{environment_id}/redeploy",
+		h.handleEnvironmentOperation("redeploy", ""))
 	h.Get("/projects/{project_id}/environments/{environment_id}/backups", h.handleListBackups)
 	h.Post("/projects/{project_id}/environments/{environment_id}/backups", h.handleCreateBackup)
+	h.Get("/projects/{project_id}/environments/{environment_id}/backups/{backup_id}", h.handleGetBackup)
+	h.Post("/projects/{project_id}/environments/{environment_id}/backups/{backup_id}/restore", h.handleRestoreBackup)
 	h.Get("/projects/{project_id}/environments/{environment_id}/deployments/current", h.handleGetCurrentDeployment)
 	h.Get("/projects/{project_id}/user-access", h.handleProjectUserAccess)
+	h.Patch("/projects/{project_id}/user-access/{user_id}", h.handlePatchProjectUserAccess)
+	h.Delete("/projects/{project_id}/user-access/{user_id}", h.handleDeleteProjectUserAccess)
+	h.Post("/projects/{project_id}/invitations", h.handleCreateProjectInvitation)
 	h.Get("/ref/projects", h.handleProjectRefs)
 
 	h.Get("/regions", h.handleListRegions)
 
 	h.Get("/projects/{project_id}/activities", h.handleListProjectActivities)
 	h.Get("/projects/{project_id}/activities/{id}", h.handleGetProjectActivity)
+	h.Get("/projects/{project_id}/activities/{id}/log", h.handleActivityLog)
 	h.Get("/projects/{project_id}/environments/{environment_id}/activities", h.handleListEnvironmentActivities)
 	h.Get("/projects/{project_id}/environments/{environment_id}/activities/{id}", h.handleGetEnvironmentActivity)
 
@@ -91,10 +110,12 @@ func NewHandler(t *testing.T) *Handler {
 	h.Post("/projects/{project_id}/variables", h.handleCreateProjectVariable)
 	h.Get("/projects/{project_id}/variables/{name}", h.handleGetProjectVariable)
 	h.Patch("/projects/{project_id}/variables/{name}", h.handlePatchProjectVariable)
+	h.Delete("/projects/{project_id}/variables/{name}", h.handleDeleteProjectVariable)
 	h.Get("/projects/{project_id}/environments/{environment_id}/variables", h.handleListEnvLevelVariables)
 	h.Post("/projects/{project_id}/environments/{environment_id}/variables", h.handleCreateEnvLevelVariable)
 	h.Get("/projects/{project_id}/environments/{environment_id}/variables/{name}", h.handleGetEnvLevelVariable)
 	h.Patch("/projects/{project_id}/environments/{environment_id}/variables/{name}", h.handlePatchEnvLevelVariable)
+	h.Delete("/projects/{project_id}/environments/{environment_id}/variables/{name}", h.handleDeleteEnvLevelVariable)
 
 	return h
 }
@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"slices"
 	"testing"
 	"time"
 
@@ -17,9 +16,6 @@ import (
 	"golang.org/x/crypto/ssh"
 )
 
-var ValidAPITokens = []string{"api-token-1"}
-var accessTokens = []string{"access-token-1"}
-
 // NewAuthServer creates a new mock authentication server.
 // The caller must call Close() on the server when finished.
 func NewAuthServer(t *testing.T) *httptest.Server {
@@ -28,25 +24,11 @@ func NewAuthServer(t *testing.T) *httptest.Server {
 		mux.Use(middleware.DefaultLogger)
 	}
 
-	mux.Post("/oauth2/token", func(w http.ResponseWriter, req *http.Request) {
-		require.NoError(t, req.ParseForm())
-		if gt := req.Form.Get("grant_type"); gt != "api_token" {
-			w.WriteHeader(http.StatusBadRequest)
-			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid grant type: " + gt})
-			return
-		}
-		apiToken := req.Form.Get("api_token")
-		if slices.Contains(ValidAPITokens, apiToken) {
-			_ = json.NewEncoder(w).Encode(struct {
-				AccessToken string `json:"access_token"`
-				ExpiresIn   int    `json:"expires_in"`
-				Type        string `json:"token_type"`
-			}{AccessToken: accessTokens[0], ExpiresIn: 60, Type: "bearer"})
-			return
-		}
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid API token"})
-	})
+	as := &authServer{t: t}
+	mux.Get("/oauth2/authorize", as.handleAuthorize)
+	mux.Post("/oauth2/token", as.handleToken)
+	mux.Post("/oauth2/device", as.handleDeviceAuthorize)
+	mux.Get("/oauth2/device/verify", as.handleDeviceVerify)
 
 	mux.Get("/ssh/authority", func(w http.ResponseWriter, _ *http.Request) {
 		pks, err := publicKeys()
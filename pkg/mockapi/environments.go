@@ -33,7 +33,7 @@ func (h *Handler) handleGetEnvironment(w http.ResponseWriter, req *http.Request)
 	for id, e := range h.environments {
 		if e.Project == projectID && id == environmentID {
 			_ = json.NewEncoder(w).Encode(e)
-			break
+			return
 		}
 	}
 	w.WriteHeader(http.StatusNotFound)
@@ -123,6 +123,59 @@ func (h *Handler) handleDeployEnvironment(w http.ResponseWriter, req *http.Reque
 	})
 }
 
+// handleEnvironmentOperation handles an operation such as "activate" or
+// "pause", which changes the environment's status and creates a completed activity.
+func (h *Handler) handleEnvironmentOperation(op, status string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		projectID := chi.URLParam(req, "project_id")
+		env := h.findEnvironment(projectID, chi.URLParam(req, "environment_id"))
+		if env == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		h.Lock()
+		if status != "" {
+			patched := *env
+			patched.Status = status
+			patched.UpdatedAt = time.Now()
+			h.environments[env.ID] = &patched
+		}
+		h.Unlock()
+
+		activity := h.addCompletedActivity(projectID, env.ID, "environment."+op,
+			"<user>Test User</user> ran "+op+" on <environment>"+env.ID+"</environment>")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"_embedded": map[string]any{"activities": []*Activity{activity}},
+		})
+	}
+}
+
+// addCompletedActivity adds a successfully completed activity, with a short log, to an environment.
+func (h *Handler) addCompletedActivity(projectID, environmentID, activityType, description string) *Activity {
+	now := time.Now()
+	activity := &Activity{
+		ID:                ulid.MustNew(ulid.Now(), rand.Reader).String(),
+		Type:              activityType,
+		State:             "complete",
+		Result:            "success",
+		CompletionPercent: 100,
+		CompletedAt:       now,
+		StartedAt:         now,
+		Project:           projectID,
+		Environments:      []string{environmentID},
+		Description:       description,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	activity.Links = MakeHALLinks(
+		"self=/projects/"+projectID+"/activities/"+activity.ID,
+		"log=/projects/"+projectID+"/activities/"+activity.ID+"/log",
+	)
+	activity.AddLog("Running "+activityType, "Done")
+	h.SetProjectActivities(projectID, []*Activity{activity})
+	return activity
+}
+
 func (h *Handler) handleGetCurrentDeployment(w http.ResponseWriter, req *http.Request) {
 	h.RLock()
 	defer h.RUnlock()
@@ -157,8 +210,54 @@ func (h *Handler) handleCreateBackup(w http.ResponseWriter, req *http.Request) {
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
+	backup.Links = MakeHALLinks(
+		"self=/projects/"+projectID+"/environments/"+environmentID+"/backups/"+backup.ID,
+		"#restore=/projects/"+projectID+"/environments/"+environmentID+"/backups/"+backup.ID+"/restore",
+	)
 	h.addProjectBackup(projectID, backup)
-	_ = json.NewEncoder(w).Encode(backup)
+
+	activity := h.addCompletedActivity(projectID, environmentID, "environment.backup",
+		"<user>Test User</user> created a backup of <environment>"+environmentID+"</environment>")
+	activity.Payload = map[string]any{"backup_name": backup.ID}
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"_embedded": map[string]any{"activities": []*Activity{activity}},
+	})
+}
+
+func (h *Handler) handleGetBackup(w http.ResponseWriter, req *http.Request) {
+	h.RLock()
+	defer h.RUnlock()
+	b := h.projectBackups[chi.URLParam(req, "project_id")][chi.URLParam(req, "backup_id")]
+	if b == nil || b.EnvironmentID != chi.URLParam(req, "environment_id") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(b)
+}
+
+func (h *Handler) handleRestoreBackup(w http.ResponseWriter, req *http.Request) {
+	projectID := chi.URLParam(req, "project_id")
+	h.RLock()
+	b := h.projectBackups[projectID][chi.URLParam(req, "backup_id")]
+	h.RUnlock()
+	if b == nil || b.EnvironmentID != chi.URLParam(req, "environment_id") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var options = struct {
+		EnvironmentName string `json:"environment_name"`
+	}{}
+	require.NoError(h.t, json.NewDecoder(req.Body).Decode(&options))
+	target := b.EnvironmentID
+	if options.EnvironmentName != "" {
+		target = options.EnvironmentName
+	}
+
+	activity := h.addCompletedActivity(projectID, target, "environment.restore",
+		"<user>Test User</user> restored <environment>"+target+"</environment> from backup "+b.ID)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"_embedded": map[string]any{"activities": []*Activity{activity}},
+	})
 }
 
 func (h *Handler) handleListBackups(w http.ResponseWriter, req *http.Request) {
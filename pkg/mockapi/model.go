@@ -24,13 +24,15 @@ func MakeHALLinks(nameAndPath ...string) HalLinks {
 }
 
 type Subscription struct {
-	ID            string   `json:"id"`
-	Links         HalLinks `json:"_links"`
-	ProjectID     string   `json:"project_id"`
-	ProjectRegion string   `json:"project_region"`
-	ProjectTitle  string   `json:"project_title"`
-	Status        string   `json:"status"`
-	ProjectUI     string   `json:"project_ui"`
+	ID             string   `json:"id"`
+	Links          HalLinks `json:"_links"`
+	OrganizationID string   `json:"organization_id"`
+	Plan           string   `json:"plan"`
+	ProjectID      string   `json:"project_id"`
+	ProjectRegion  string   `json:"project_region"`
+	ProjectTitle   string   `json:"project_title"`
+	Status         string   `json:"status"`
+	ProjectUI      string   `json:"project_ui"`
 }
 
 type CanCreateRequiredAction struct {
@@ -188,6 +190,69 @@ type OrgRef struct {
 	Owner string `json:"owner_id"`
 }
 
+type OrgMember struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	UserID         string    `json:"user_id"`
+	Permissions    []string  `json:"permissions"`
+	Level          string    `json:"level"`
+	Owner          bool      `json:"owner"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	Links          HalLinks  `json:"_links"`
+}
+
+type OrgInvitation struct {
+	ID          string    `json:"id"`
+	State       string    `json:"state"`
+	Email       string    `json:"email"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Region struct {
+	ID                  string                     `json:"id"`
+	Label               string                     `json:"label"`
+	SelectionLabel      string                     `json:"selection_label"`
+	Zone                string                     `json:"zone"`
+	Timezone            string                     `json:"timezone"`
+	Available           bool                       `json:"available"`
+	Private             bool                       `json:"private"`
+	Provider            RegionProvider             `json:"provider"`
+	Datacenter          RegionDatacenter           `json:"datacenter"`
+	EnvironmentalImpact *RegionEnvironmentalImpact `json:"environmental_impact,omitempty"`
+}
+
+type RegionProvider struct {
+	Name string `json:"name"`
+}
+
+type RegionDatacenter struct {
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	Location string `json:"location"`
+}
+
+type RegionEnvironmentalImpact struct {
+	Zone            string `json:"zone"`
+	CarbonIntensity int    `json:"carbon_intensity"`
+	Green           bool   `json:"green"`
+}
+
+type ProjectInvitation struct {
+	ID          string                        `json:"id"`
+	State       string                        `json:"state"`
+	Email       string                        `json:"email"`
+	Role        string                        `json:"role"`
+	Permissions []ProjectInvitationPermission `json:"permissions"`
+	CreatedAt   time.Time                     `json:"created_at"`
+}
+
+type ProjectInvitationPermission struct {
+	Type string `json:"type"`
+	Role string `json:"role"`
+}
+
 type ProjectUserGrant struct {
 	ProjectID      string    `json:"project_id"`
 	OrganizationID string    `json:"organization_id"`
@@ -290,11 +355,69 @@ type Activity struct {
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	Links HalLinks `json:"_links"`
+
+	log         []ActivityLogEntry
+	transitions []ActivityTransition
+}
+
+// ActivityLogEntry is an entry in an activity's log.
+type ActivityLogEntry struct {
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ActivityTransition is a change in an activity's state, which is applied
+// when the activity is next fetched.
+type ActivityTransition struct {
+	State             string
+	Result            string
+	CompletionPercent int
+
+	// Log messages which are added when the transition is applied.
+	Log []string
+}
+
+// AddLog adds messages to the activity's log, each one second apart.
+func (a *Activity) AddLog(messages ...string) {
+	for _, m := range messages {
+		a.log = append(a.log, ActivityLogEntry{
+			Message:   m,
+			Timestamp: a.CreatedAt.Add(time.Duration(len(a.log)+1) * time.Second),
+		})
+	}
+}
+
+// AddTransitions queues state transitions, one of which is applied each time the activity is fetched.
+func (a *Activity) AddTransitions(t ...ActivityTransition) {
+	a.transitions = append(a.transitions, t...)
+}
+
+// nextTransition applies the next queued state transition, if any.
+func (a *Activity) nextTransition() {
+	if len(a.transitions) == 0 {
+		return
+	}
+	t := a.transitions[0]
+	a.transitions = a.transitions[1:]
+	a.State = t.State
+	a.Result = t.Result
+	a.CompletionPercent = t.CompletionPercent
+	a.UpdatedAt = time.Now()
+	if a.StartedAt.IsZero() && t.State != "pending" {
+		a.StartedAt = a.UpdatedAt
+	}
+	if t.State == "complete" || t.State == "cancelled" {
+		a.CompletedAt = a.UpdatedAt
+	}
+	a.AddLog(t.Log...)
 }
 
 type Variable struct {
 	Name             string   `json:"name"`
 	Value            string   `json:"value,omitempty"`
+	IsJSON           bool     `json:"is_json"`
 	IsSensitive      bool     `json:"is_sensitive"`
 	VisibleBuild     bool     `json:"visible_build"`
 	VisibleRuntime   bool     `json:"visible_runtime"`
@@ -306,6 +429,15 @@ type Variable struct {
 	Links HalLinks `json:"_links"`
 }
 
+// readable returns a copy of the variable as it can be read from the API, i.e. without a sensitive value.
+func (v *Variable) readable() *Variable {
+	c := *v
+	if c.IsSensitive {
+		c.Value = ""
+	}
+	return &c
+}
+
 type EnvLevelVariable struct {
 	Variable
 
@@ -0,0 +1,210 @@
+package mockapi
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var ValidAPITokens = []string{"api-token-1"}
+var ValidRefreshTokens = []string{"refresh-token-1"}
+var accessTokens = []string{"access-token-1"}
+
+// authServer holds the state of the mock OAuth2 server.
+type authServer struct {
+	t *testing.T
+
+	mu sync.Mutex
+	// codes maps issued authorization codes to their grant.
+	codes map[string]*authCodeGrant
+	// devices maps issued device codes to their grant.
+	devices map[string]*deviceGrant
+}
+
+// deviceGrant is the state of a device authorization request (RFC 8628).
+type deviceGrant struct {
+	userCode string
+	expiry   time.Time
+	polled   bool
+	approved bool
+	denied   bool
+}
+
+type authCodeGrant struct {
+	redirectURI   string
+	codeChallenge string
+}
+
+// handleAuthorize immediately approves an authorization request, redirecting
+// back to the client with an authorization code. Only PKCE with S256 is supported.
+func (as *authServer) handleAuthorize(w http.ResponseWriter, req *http.Request) {
+	q := req.URL.Query()
+	redirectURI, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || redirectURI.Host == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_request"})
+		return
+	}
+	redirectQuery := redirectURI.Query()
+	redirectQuery.Set("state", q.Get("state"))
+
+	if q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		redirectQuery.Set("error", "invalid_request")
+		redirectURI.RawQuery = redirectQuery.Encode()
+		http.Redirect(w, req, redirectURI.String(), http.StatusFound)
+		return
+	}
+
+	code := lowercaseAlphanumericID(32)
+	as.mu.Lock()
+	if as.codes == nil {
+		as.codes = make(map[string]*authCodeGrant)
+	}
+	as.codes[code] = &authCodeGrant{redirectURI: q.Get("redirect_uri"), codeChallenge: q.Get("code_challenge")}
+	as.mu.Unlock()
+
+	redirectQuery.Set("code", code)
+	redirectURI.RawQuery = redirectQuery.Encode()
+	http.Redirect(w, req, redirectURI.String(), http.StatusFound)
+}
+
+func (as *authServer) handleToken(w http.ResponseWriter, req *http.Request) {
+	require.NoError(as.t, req.ParseForm())
+	switch gt := req.Form.Get("grant_type"); gt {
+	case "api_token":
+		if slices.Contains(ValidAPITokens, req.Form.Get("api_token")) {
+			writeAccessToken(w, "")
+			return
+		}
+		writeTokenError(w, "invalid API token")
+	case "refresh_token":
+		refreshToken := req.Form.Get("refresh_token")
+		if slices.Contains(ValidRefreshTokens, refreshToken) {
+			writeAccessToken(w, refreshToken)
+			return
+		}
+		writeTokenError(w, "invalid_grant")
+	case "authorization_code":
+		as.mu.Lock()
+		grant := as.codes[req.Form.Get("code")]
+		delete(as.codes, req.Form.Get("code"))
+		as.mu.Unlock()
+		if grant == nil || grant.redirectURI != req.Form.Get("redirect_uri") {
+			writeTokenError(w, "invalid_grant")
+			return
+		}
+		challenge := sha256.Sum256([]byte(req.Form.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(challenge[:]) != grant.codeChallenge {
+			writeTokenError(w, "invalid_grant")
+			return
+		}
+		writeAccessToken(w, ValidRefreshTokens[0])
+	case "urn:ietf:params:oauth:grant-type:device_code":
+		as.handleDeviceToken(w, req.Form.Get("device_code"))
+	default:
+		writeTokenError(w, "invalid grant type: "+gt)
+	}
+}
+
+// handleDeviceAuthorize starts a device authorization request.
+func (as *authServer) handleDeviceAuthorize(w http.ResponseWriter, req *http.Request) {
+	deviceCode := lowercaseAlphanumericID(32)
+	userCode := strings.ToUpper(lowercaseAlphanumericID(8))
+	verificationURI := "http://" + req.Host + "/oauth2/device/verify"
+
+	as.mu.Lock()
+	if as.devices == nil {
+		as.devices = make(map[string]*deviceGrant)
+	}
+	as.devices[deviceCode] = &deviceGrant{userCode: userCode, expiry: time.Now().Add(10 * time.Minute)}
+	as.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"device_code":               deviceCode,
+		"user_code":                 userCode,
+		"verification_uri":          verificationURI,
+		"verification_uri_complete": verificationURI + "?user_code=" + url.QueryEscape(userCode),
+		"expires_in":                600,
+		"interval":                  1,
+	})
+}
+
+// handleDeviceVerify imitates the user approving (or, with "deny=1", denying) a
+// device authorization request.
+func (as *authServer) handleDeviceVerify(w http.ResponseWriter, req *http.Request) {
+	userCode := req.URL.Query().Get("user_code")
+	deny := req.URL.Query().Get("deny") == "1"
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	for _, g := range as.devices {
+		if g.userCode == userCode {
+			g.approved = !deny
+			g.denied = deny
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// handleDeviceToken responds to a device code token request. The first poll is
+// asked to slow down, to exercise client interval handling.
+func (as *authServer) handleDeviceToken(w http.ResponseWriter, deviceCode string) {
+	as.mu.Lock()
+	g := as.devices[deviceCode]
+	var errCode string
+	switch {
+	case g == nil:
+		errCode = "invalid_grant"
+	case time.Now().After(g.expiry):
+		errCode = "expired_token"
+	case g.denied:
+		errCode = "access_denied"
+	case !g.polled:
+		errCode = "slow_down"
+	case !g.approved:
+		errCode = "authorization_pending"
+	}
+	if g != nil {
+		g.polled = true
+		// The grant is used up once a token is issued or access is denied.
+		if errCode == "" || errCode == "access_denied" {
+			delete(as.devices, deviceCode)
+		}
+	}
+	as.mu.Unlock()
+
+	if errCode != "" {
+		writeTokenError(w, errCode)
+		return
+	}
+	writeAccessToken(w, ValidRefreshTokens[0])
+}
+
+// writeAccessToken writes a token response, optionally including a refresh token.
+func writeAccessToken(w http.ResponseWriter, refreshToken string) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		AccessToken  string `json:"access_token"`
+		ExpiresIn    int    `json:"expires_in"`
+		Type         string `json:"token_type"`
+		RefreshToken string `json:"refresh_token,omitempty"`
+	}{AccessToken: accessTokens[0], ExpiresIn: 60, Type: "bearer", RefreshToken: refreshToken})
+}
+
+func writeTokenError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
@@ -8,6 +8,7 @@ import (
 	"path"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/oklog/ulid/v2"
@@ -118,3 +119,97 @@ func (h *Handler) handlePatchOrg(w http.ResponseWriter, req *http.Request) {
 	h.orgs[orgID] = &patched
 	_ = json.NewEncoder(w).Encode(&patched)
 }
+
+func (h *Handler) handleListOrgMembers(w http.ResponseWriter, req *http.Request) {
+	h.RLock()
+	defer h.RUnlock()
+	orgID := chi.URLParam(req, "organization_id")
+	if h.orgs[orgID] == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var (
+		members = h.orgMembers[orgID]
+		userIDs = make(uniqueMap)
+	)
+	for _, m := range members {
+		userIDs[m.UserID] = struct{}{}
+	}
+	if members == nil {
+		members = []*OrgMember{}
+	}
+	_ = json.NewEncoder(w).Encode(struct {
+		Items []*OrgMember `json:"items"`
+		Links HalLinks     `json:"_links"`
+	}{
+		Items: members,
+		Links: MakeHALLinks("ref:users:0=/ref/users?in=" + strings.Join(userIDs.keys(), ",")),
+	})
+}
+
+func (h *Handler) findOrgMember(req *http.Request) (orgID string, index int) {
+	orgID = chi.URLParam(req, "organization_id")
+	userID := chi.URLParam(req, "user_id")
+	index = slices.IndexFunc(h.orgMembers[orgID], func(m *OrgMember) bool { return m.UserID == userID })
+	return orgID, index
+}
+
+func (h *Handler) handlePatchOrgMember(w http.ResponseWriter, req *http.Request) {
+	h.Lock()
+	defer h.Unlock()
+	orgID, i := h.findOrgMember(req)
+	if i == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	patched := *h.orgMembers[orgID][i]
+	if err := json.NewDecoder(req.Body).Decode(&patched); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	patched.UpdatedAt = time.Now()
+	h.orgMembers[orgID][i] = &patched
+	_ = json.NewEncoder(w).Encode(&patched)
+}
+
+func (h *Handler) handleDeleteOrgMember(w http.ResponseWriter, req *http.Request) {
+	h.Lock()
+	defer h.Unlock()
+	orgID, i := h.findOrgMember(req)
+	if i == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if h.orgMembers[orgID][i].Owner {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	h.orgMembers[orgID] = slices.Delete(h.orgMembers[orgID], i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleCreateOrgInvitation(w http.ResponseWriter, req *http.Request) {
+	h.Lock()
+	defer h.Unlock()
+	orgID := chi.URLParam(req, "organization_id")
+	var inv OrgInvitation
+	if err := json.NewDecoder(req.Body).Decode(&inv); err != nil || inv.Email == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	for _, m := range h.orgMembers[orgID] {
+		// User references are mocked with the email address <user_id>@example.com.
+		if m.UserID+"@example.com" == inv.Email {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+	}
+	inv.ID = ulid.MustNew(ulid.Now(), rand.Reader).String()
+	inv.State = "pending"
+	inv.CreatedAt = time.Now()
+	if h.orgInvitations == nil {
+		h.orgInvitations = make(map[string][]*OrgInvitation)
+	}
+	h.orgInvitations[orgID] = append(h.orgInvitations[orgID], &inv)
+	_ = json.NewEncoder(w).Encode(&inv)
+}
@@ -1,12 +1,15 @@
 package mockapi
 
 import (
+	"crypto/rand"
 	"encoding/json"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -58,21 +61,20 @@ func (h *Handler) handlePatchProject(w http.ResponseWriter, req *http.Request) {
 }
 
 func (h *Handler) handleListRegions(w http.ResponseWriter, _ *http.Request) {
-	type region struct {
-		ID             string `json:"id"`
-		Label          string `json:"label"`
-		SelectionLabel string `json:"selection_label"`
-		Available      bool   `json:"available"`
-	}
-	type regions struct {
-		Regions []region `json:"regions"`
+	h.RLock()
+	defer h.RUnlock()
+	regions := h.regions
+	if regions == nil {
+		regions = []*Region{{
+			ID:             "test-region",
+			Label:          "Test Region",
+			SelectionLabel: "Test Region",
+			Available:      true,
+		}}
 	}
-	_ = json.NewEncoder(w).Encode(regions{[]region{{
-		ID:             "test-region",
-		Label:          "Test Region",
-		SelectionLabel: "Test Region",
-		Available:      true,
-	}}})
+	_ = json.NewEncoder(w).Encode(struct {
+		Regions []*Region `json:"regions"`
+	}{regions})
 }
 
 func (h *Handler) handleProjectUserAccess(w http.ResponseWriter, req *http.Request) {
@@ -108,3 +110,78 @@ func (h *Handler) handleProjectUserAccess(w http.ResponseWriter, req *http.Reque
 	)}
 	_ = json.NewEncoder(w).Encode(ret)
 }
+
+func (h *Handler) findProjectUserGrant(req *http.Request) int {
+	projectID := chi.URLParam(req, "project_id")
+	userID := chi.URLParam(req, "user_id")
+	return slices.IndexFunc(h.userGrants, func(g *UserGrant) bool {
+		return g.ResourceType == "project" && g.ResourceID == projectID && g.UserID == userID
+	})
+}
+
+func (h *Handler) handlePatchProjectUserAccess(w http.ResponseWriter, req *http.Request) {
+	h.Lock()
+	defer h.Unlock()
+	i := h.findProjectUserGrant(req)
+	if i == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var patch struct {
+		Permissions []string `json:"permissions"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&patch); err != nil || len(patch.Permissions) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	patched := *h.userGrants[i]
+	patched.Permissions = patch.Permissions
+	patched.UpdatedAt = time.Now()
+	h.userGrants[i] = &patched
+	_ = json.NewEncoder(w).Encode(&ProjectUserGrant{
+		ProjectID:      patched.ResourceID,
+		OrganizationID: patched.OrganizationID,
+		UserID:         patched.UserID,
+		Permissions:    patched.Permissions,
+		GrantedAt:      patched.GrantedAt,
+		UpdatedAt:      patched.UpdatedAt,
+	})
+}
+
+func (h *Handler) handleDeleteProjectUserAccess(w http.ResponseWriter, req *http.Request) {
+	h.Lock()
+	defer h.Unlock()
+	i := h.findProjectUserGrant(req)
+	if i == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	h.userGrants = slices.Delete(h.userGrants, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleCreateProjectInvitation(w http.ResponseWriter, req *http.Request) {
+	h.Lock()
+	defer h.Unlock()
+	projectID := chi.URLParam(req, "project_id")
+	var inv ProjectInvitation
+	if err := json.NewDecoder(req.Body).Decode(&inv); err != nil || inv.Email == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	for _, g := range h.userGrants {
+		// User references are mocked with the email address <user_id>@example.com.
+		if g.ResourceType == "project" && g.ResourceID == projectID && g.UserID+"@example.com" == inv.Email {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+	}
+	inv.ID = ulid.MustNew(ulid.Now(), rand.Reader).String()
+	inv.State = "pending"
+	inv.CreatedAt = time.Now()
+	if h.projectInvitations == nil {
+		h.projectInvitations = make(map[string][]*ProjectInvitation)
+	}
+	h.projectInvitations[projectID] = append(h.projectInvitations[projectID], &inv)
+	_ = json.NewEncoder(w).Encode(&inv)
+}
@@ -11,10 +11,16 @@ type store struct {
 	projects      map[string]*Project
 	environments  map[string]*Environment
 	subscriptions map[string]*Subscription
+	regions       []*Region
 	userGrants    []*UserGrant
 
 	canCreate map[string]*CanCreateResponse
 
+	orgMembers     map[string][]*OrgMember
+	orgInvitations map[string][]*OrgInvitation
+
+	projectInvitations map[string][]*ProjectInvitation
+
 	activities        map[string]map[string]*Activity
 	projectBackups    map[string]map[string]*Backup
 	projectVariables  map[string][]*Variable
@@ -59,6 +65,44 @@ func (s *store) SetOrgs(orgs []*Org) {
 	}
 }
 
+func (s *store) SetRegions(regions []*Region) {
+	s.Lock()
+	defer s.Unlock()
+	s.regions = regions
+}
+
+func (s *store) SetSubscriptions(subs []*Subscription) {
+	s.Lock()
+	defer s.Unlock()
+	s.subscriptions = make(map[string]*Subscription, len(subs))
+	for _, sub := range subs {
+		s.subscriptions[sub.ID] = sub
+	}
+}
+
+func (s *store) SetOrgMembers(orgID string, members []*OrgMember) {
+	s.Lock()
+	defer s.Unlock()
+	if s.orgMembers == nil {
+		s.orgMembers = make(map[string][]*OrgMember)
+	}
+	s.orgMembers[orgID] = members
+}
+
+// OrgInvitations returns the invitations which have been sent for an organization.
+func (s *store) OrgInvitations(orgID string) []*OrgInvitation {
+	s.RLock()
+	defer s.RUnlock()
+	return s.orgInvitations[orgID]
+}
+
+// ProjectInvitations returns the invitations which have been sent for a project.
+func (s *store) ProjectInvitations(projectID string) []*ProjectInvitation {
+	s.RLock()
+	defer s.RUnlock()
+	return s.projectInvitations[projectID]
+}
+
 func (s *store) SetCanCreate(orgID string, r *CanCreateResponse) {
 	s.Lock()
 	defer s.Unlock()
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -15,6 +17,7 @@ func (h *Handler) handleCreateSubscription(w http.ResponseWriter, req *http.Requ
 	var createOptions = struct {
 		Region string `json:"project_region"`
 		Title  string `json:"project_title"`
+		Plan   string `json:"plan"`
 	}{}
 	err := json.NewDecoder(req.Body).Decode(&createOptions)
 	require.NoError(h.t, err)
@@ -26,9 +29,11 @@ func (h *Handler) handleCreateSubscription(w http.ResponseWriter, req *http.Requ
 		Links: MakeHALLinks(
 			"self=" + "/organizations/" + url.PathEscape(orgID) + "/subscriptions/" + url.PathEscape(id),
 		),
-		ProjectRegion: createOptions.Region,
-		ProjectTitle:  createOptions.Title,
-		Status:        "provisioning",
+		OrganizationID: orgID,
+		Plan:           createOptions.Plan,
+		ProjectRegion:  createOptions.Region,
+		ProjectTitle:   createOptions.Title,
+		Status:         "provisioning",
 	}
 
 	h.Lock()
@@ -87,3 +92,20 @@ func (h *Handler) handleCanCreateSubscriptions(w http.ResponseWriter, req *http.
 	}
 	_ = json.NewEncoder(w).Encode(cc)
 }
+
+func (h *Handler) handleListOrgSubscriptions(w http.ResponseWriter, req *http.Request) {
+	h.RLock()
+	defer h.RUnlock()
+	orgID := chi.URLParam(req, "organization_id")
+	subs := make([]*Subscription, 0, len(h.subscriptions))
+	for _, sub := range h.subscriptions {
+		if sub.OrganizationID == orgID {
+			subs = append(subs, sub)
+		}
+	}
+	slices.SortFunc(subs, func(a, b *Subscription) int { return strings.Compare(a.ID, b.ID) })
+	_ = json.NewEncoder(w).Encode(struct {
+		Items []*Subscription `json:"items"`
+		Links HalLinks        `json:"_links"`
+	}{Items: subs, Links: MakeHALLinks()})
+}
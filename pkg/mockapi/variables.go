@@ -14,7 +14,10 @@ func (h *Handler) handleListProjectVariables(w http.ResponseWriter, req *http.Re
 	h.RLock()
 	defer h.RUnlock()
 	projectID := chi.URLParam(req, "project_id")
-	variables := h.projectVariables[projectID]
+	variables := make([]*Variable, len(h.projectVariables[projectID]))
+	for i, v := range h.projectVariables[projectID] {
+		variables[i] = v.readable()
+	}
 	// Sort variables in descending order by created date.
 	slices.SortFunc(variables, func(a, b *Variable) int { return -timeCompare(a.CreatedAt, b.CreatedAt) })
 	_ = json.NewEncoder(w).Encode(variables)
@@ -27,7 +30,7 @@ func (h *Handler) handleGetProjectVariable(w http.ResponseWriter, req *http.Requ
 	variableName, _ := url.PathUnescape(chi.URLParam(req, "name"))
 	for _, v := range h.projectVariables[projectID] {
 		if variableName == v.Name {
-			_ = json.NewEncoder(w).Encode(v)
+			_ = json.NewEncoder(w).Encode(v.readable())
 			return
 		}
 	}
@@ -50,6 +53,7 @@ func (h *Handler) handleCreateProjectVariable(w http.ResponseWriter, req *http.R
 	newVar.Links = MakeHALLinks(
 		"self=/projects/"+projectID+"/variables/"+newVar.Name,
 		"#edit=/projects/"+projectID+"/variables/"+newVar.Name,
+		"#delete=/projects/"+projectID+"/variables/"+newVar.Name,
 	)
 
 	for _, v := range h.projectVariables[projectID] {
@@ -59,7 +63,7 @@ func (h *Handler) handleCreateProjectVariable(w http.ResponseWriter, req *http.R
 		}
 	}
 
-	if h.projectVariables[projectID] == nil {
+	if h.projectVariables == nil {
 		h.projectVariables = make(map[string][]*Variable)
 	}
 	h.projectVariables[projectID] = append(h.projectVariables[projectID], &newVar)
@@ -84,6 +88,7 @@ func (h *Handler) handlePatchProjectVariable(w http.ResponseWriter, req *http.Re
 	}
 	if key == -1 {
 		w.WriteHeader(http.StatusNotFound)
+		return
 	}
 	patched := *h.projectVariables[projectID][key]
 	err := json.NewDecoder(req.Body).Decode(&patched)
@@ -101,7 +106,12 @@ func (h *Handler) handleListEnvLevelVariables(w http.ResponseWriter, req *http.R
 	defer h.RUnlock()
 	projectID := chi.URLParam(req, "project_id")
 	environmentID, _ := url.PathUnescape(chi.URLParam(req, "environment_id"))
-	variables := h.envLevelVariables[projectID][environmentID]
+	variables := make([]*EnvLevelVariable, len(h.envLevelVariables[projectID][environmentID]))
+	for i, v := range h.envLevelVariables[projectID][environmentID] {
+		readable := *v
+		readable.Variable = *v.readable()
+		variables[i] = &readable
+	}
 	// Sort variables in descending order by created date.
 	slices.SortFunc(variables, func(a, b *EnvLevelVariable) int { return -timeCompare(a.CreatedAt, b.CreatedAt) })
 	_ = json.NewEncoder(w).Encode(variables)
@@ -115,7 +125,9 @@ func (h *Handler) handleGetEnvLevelVariable(w http.ResponseWriter, req *http.Req
 	variableName, _ := url.PathUnescape(chi.URLParam(req, "name"))
 	for _, v := range h.envLevelVariables[projectID][environmentID] {
 		if variableName == v.Name {
-			_ = json.NewEncoder(w).Encode(v)
+			readable := *v
+			readable.Variable = *v.readable()
+			_ = json.NewEncoder(w).Encode(&readable)
 			return
 		}
 	}
@@ -139,6 +151,7 @@ func (h *Handler) handleCreateEnvLevelVariable(w http.ResponseWriter, req *http.
 	newVar.Links = MakeHALLinks(
 		"self=/projects/"+projectID+"/environments/"+environmentID+"/variables/"+newVar.Name,
 		"#edit=/projects/"+projectID+"/environments/"+environmentID+"/variables/"+newVar.Name,
+		"#delete=/projects/"+projectID+"/environments/"+environmentID+"/variables/"+newVar.Name,
 	)
 
 	for _, v := range h.envLevelVariables[projectID][environmentID] {
@@ -179,6 +192,7 @@ func (h *Handler) handlePatchEnvLevelVariable(w http.ResponseWriter, req *http.R
 	}
 	if key == -1 {
 		w.WriteHeader(http.StatusNotFound)
+		return
 	}
 	patched := *h.envLevelVariables[projectID][environmentID][key]
 	err := json.NewDecoder(req.Body).Decode(&patched)
@@ -190,3 +204,38 @@ func (h *Handler) handlePatchEnvLevelVariable(w http.ResponseWriter, req *http.R
 	h.envLevelVariables[projectID][environmentID][key] = &patched
 	_ = json.NewEncoder(w).Encode(&patched)
 }
+
+func (h *Handler) handleDeleteProjectVariable(w http.ResponseWriter, req *http.Request) {
+	h.Lock()
+	defer h.Unlock()
+	projectID := chi.URLParam(req, "project_id")
+	variableName, _ := url.PathUnescape(chi.URLParam(req, "name"))
+	vars := h.projectVariables[projectID]
+	i := slices.IndexFunc(vars, func(v *Variable) bool { return v.Name == variableName })
+	if i == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	h.projectVariables[projectID] = slices.Delete(vars, i, i+1)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"_embedded": map[string]any{"activities": []*Activity{}},
+	})
+}
+
+func (h *Handler) handleDeleteEnvLevelVariable(w http.ResponseWriter, req *http.Request) {
+	h.Lock()
+	defer h.Unlock()
+	projectID := chi.URLParam(req, "project_id")
+	environmentID, _ := url.PathUnescape(chi.URLParam(req, "environment_id"))
+	variableName, _ := url.PathUnescape(chi.URLParam(req, "name"))
+	vars := h.envLevelVariables[projectID][environmentID]
+	i := slices.IndexFunc(vars, func(v *EnvLevelVariable) bool { return v.Name == variableName })
+	if i == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	h.envLevelVariables[projectID][environmentID] = slices.Delete(vars, i, i+1)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"_embedded": map[string]any{"activities": []*Activity{}},
+	})
+}